@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// annotationsCmd groups the annotation sub-commands
+var annotationsCmd = &cobra.Command{
+	Use:   "annotations",
+	Short: "Manage timeline annotations of a Perfana run",
+}
+
+var annotationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the annotations of a test run",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		annotations, err := client.GetTestRunAnnotations(testRunID)
+		if err != nil {
+			fmt.Printf("Error listing annotations: %v\n", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTIMESTAMP\tTITLE\tTAGS\tDESCRIPTION")
+		for _, a := range annotations {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.ID, a.Timestamp.Format(time.RFC3339), a.Title, strings.Join(a.Tags, ","), a.Description)
+		}
+		w.Flush()
+	},
+}
+
+var annotationsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an annotation from a test run",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		annotationID, _ := cmd.Flags().GetString("annotation-id")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.DeleteTestRunAnnotation(testRunID, annotationID); err != nil {
+			fmt.Printf("Error deleting annotation: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Annotation %s deleted\n", annotationID)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(annotationsCmd)
+	annotationsCmd.AddCommand(annotationsListCmd, annotationsDeleteCmd)
+
+	annotationsListCmd.Flags().String("testRunId", "", "ID of the test run")
+	_ = annotationsListCmd.MarkFlagRequired("testRunId")
+
+	annotationsDeleteCmd.Flags().String("testRunId", "", "ID of the test run")
+	annotationsDeleteCmd.Flags().String("annotation-id", "", "ID of the annotation to delete")
+	_ = annotationsDeleteCmd.MarkFlagRequired("testRunId")
+	_ = annotationsDeleteCmd.MarkFlagRequired("annotation-id")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Flags for deriving the CI build results URL from the CI environment
+var (
+	ciBuildURLFromEnv bool
+	ciSystem          string
+)
+
+// detectCISystem returns the CI system the command runs in, based on system-specific environment variables.
+func detectCISystem() (string, error) {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") != "":
+		return "github", nil
+	case os.Getenv("GITLAB_CI") != "":
+		return "gitlab", nil
+	case os.Getenv("JENKINS_URL") != "":
+		return "jenkins", nil
+	case os.Getenv("CIRCLECI") != "":
+		return "circle", nil
+	case os.Getenv("TEKTON_PIPELINE_RUN") != "":
+		return "tekton", nil
+	}
+	return "", fmt.Errorf("no supported CI system detected")
+}
+
+// ciBuildURL constructs the build results URL of the current CI job from the
+// environment variables of the given CI system; "auto" detects the system.
+func ciBuildURL(system string) (string, error) {
+	if system == "auto" {
+		detected, err := detectCISystem()
+		if err != nil {
+			return "", err
+		}
+		system = detected
+	}
+
+	var url string
+	var required []string
+	switch system {
+	case "github":
+		required = []string{"GITHUB_SERVER_URL", "GITHUB_REPOSITORY", "GITHUB_RUN_ID"}
+		url = fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))
+	case "gitlab":
+		required = []string{"CI_JOB_URL"}
+		url = os.Getenv("CI_JOB_URL")
+	case "jenkins":
+		required = []string{"BUILD_URL"}
+		url = os.Getenv("BUILD_URL")
+	case "circle":
+		required = []string{"CIRCLE_BUILD_URL"}
+		url = os.Getenv("CIRCLE_BUILD_URL")
+	case "tekton":
+		// Tekton does not expose a build URL itself; the pipeline passes these in.
+		required = []string{"TEKTON_DASHBOARD_URL", "TEKTON_NAMESPACE", "TEKTON_PIPELINE_RUN"}
+		url = fmt.Sprintf("%s/#/namespaces/%s/pipelineruns/%s",
+			strings.TrimSuffix(os.Getenv("TEKTON_DASHBOARD_URL"), "/"), os.Getenv("TEKTON_NAMESPACE"), os.Getenv("TEKTON_PIPELINE_RUN"))
+	default:
+		return "", fmt.Errorf("unsupported CI system %q (expected auto, github, gitlab, jenkins, circle or tekton)", system)
+	}
+
+	for _, name := range required {
+		if os.Getenv(name) == "" {
+			return "", fmt.Errorf("environment variable %s not set for CI system %s", name, system)
+		}
+	}
+	return url, nil
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&ciBuildURLFromEnv, "ci-build-url-from-env", false, "Derive the CI build results URL from the environment variables of the CI system")
+	startCmd.Flags().StringVar(&ciSystem, "ci-system", "auto", "CI system for --ci-build-url-from-env: auto, github, gitlab, jenkins, circle or tekton")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"perfana-cli/perfana_client"
+	"perfana-cli/util"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadFullConfig reads and parses the perfana.yaml returned by configFilePath.
+// Environment variables in the file are expanded. When no file exists at the default
+// location an empty configuration is returned, so the perfana section can be set with
+// PERFANA_* environment variables alone; an explicit --config or PERFANA_CONFIG path
+// must exist.
+func loadFullConfig() (*FullConfig, error) {
+	configPath, explicit, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.ReadFile(configPath)
+	if !explicit && errors.Is(err, os.ErrNotExist) {
+		return &FullConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	// Expand environment variables in YAML content
+	expandedContent := os.ExpandEnv(string(file))
+
+	var fullConfig FullConfig
+	if err := yaml.Unmarshal([]byte(expandedContent), &fullConfig); err != nil {
+		return nil, fmt.Errorf("error parsing configuration file: %w", err)
+	}
+
+	return &fullConfig, nil
+}
+
+// configFilePath returns the path of perfana.yaml: the --config flag or the
+// PERFANA_CONFIG environment variable when set, otherwise ~/.perfana-cli/perfana.yaml,
+// falling back to ./perfana.yaml when that file does not exist. explicit reports whether
+// the path was set with --config or PERFANA_CONFIG.
+func configFilePath() (configPath string, explicit bool, err error) {
+	configPath, explicit, err = util.ResolveConfigPath(cfgFile)
+	if err != nil {
+		return "", false, err
+	}
+
+	// Also check for ./perfana.yaml in current directory
+	if _, err := os.Stat(configPath); !explicit && os.IsNotExist(err) {
+		if _, err2 := os.Stat("perfana.yaml"); err2 == nil {
+			configPath = "perfana.yaml"
+		}
+	}
+	return configPath, explicit, nil
+}
+
+// clientConfig returns the Perfana client configuration, applying the test
+// settings when they are not set in the perfana section directly. PERFANA_*
+// environment variables override the file values.
+func clientConfig(fullConfig *FullConfig) perfana_client.Configuration {
+	config := fullConfig.Perfana
+	perfana_client.ApplyEnv(&config)
+	if config.SystemUnderTest == "" {
+		config.SystemUnderTest = fullConfig.Test.SystemUnderTest
+	}
+	if config.Environment == "" {
+		config.Environment = fullConfig.Test.Environment
+	}
+	if config.Workload == "" {
+		config.Workload = fullConfig.Test.Workload
+	}
+	return config
+}
+
+// loadClient loads the configuration and initializes a Perfana client from it.
+func loadClient() (*perfana_client.PerfanaClient, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := perfana_client.NewClient(clientConfig(fullConfig))
+	if err != nil {
+		return nil, fmt.Errorf("error initializing Perfana client: %w", err)
+	}
+	return client, nil
+}
+
+// cliRequestTimeout limits the Perfana requests of the run event and run stop commands.
+const cliRequestTimeout = 30 * time.Second
+
+// requestContext returns the context for a single Perfana request of a command. It is
+// cancelled on SIGINT/SIGTERM, so Ctrl-C stops a hanging request, and after cliRequestTimeout.
+func requestContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithTimeout(ctx, cliRequestTimeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
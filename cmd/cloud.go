@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cloudMetadataTimeout limits the total time spent querying instance metadata endpoints.
+const cloudMetadataTimeout = 3 * time.Second
+
+// fetchCloudMetadata queries the instance metadata endpoint of the given cloud provider
+// (AWS, GCP or AZURE) and returns cloud.* variables describing the instance.
+func fetchCloudMetadata(provider string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cloudMetadataTimeout)
+	defer cancel()
+
+	var region, zone, instanceType string
+	var err error
+
+	switch strings.ToUpper(provider) {
+	case "AWS":
+		region, zone, instanceType, err = fetchAWSMetadata(ctx)
+	case "GCP":
+		region, zone, instanceType, err = fetchGCPMetadata(ctx)
+	case "AZURE":
+		region, zone, instanceType, err = fetchAzureMetadata(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %q (expected AWS, GCP or AZURE)", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]string{
+		"cloud.provider":      strings.ToUpper(provider),
+		"cloud.region":        region,
+		"cloud.instance-type": instanceType,
+	}
+	if zone != "" {
+		variables["cloud.zone"] = zone
+	}
+	return variables, nil
+}
+
+// fetchAWSMetadata uses IMDSv2: a session token is requested first and sent with each query.
+func fetchAWSMetadata(ctx context.Context) (string, string, string, error) {
+	const base = "http://169.254.169.254/latest"
+
+	token, err := metadataRequest(ctx, "PUT", base+"/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+	headers := map[string]string{"X-aws-ec2-metadata-token": token}
+
+	region, err := metadataRequest(ctx, "GET", base+"/meta-data/placement/region", headers)
+	if err != nil {
+		return "", "", "", err
+	}
+	zone, err := metadataRequest(ctx, "GET", base+"/meta-data/placement/availability-zone", headers)
+	if err != nil {
+		return "", "", "", err
+	}
+	instanceType, err := metadataRequest(ctx, "GET", base+"/meta-data/instance-type", headers)
+	if err != nil {
+		return "", "", "", err
+	}
+	return region, zone, instanceType, nil
+}
+
+// fetchGCPMetadata reads zone and machine type; both are returned as resource paths
+// (e.g. projects/123/zones/europe-west1-b), so only the last segment is kept.
+func fetchGCPMetadata(ctx context.Context) (string, string, string, error) {
+	const base = "http://metadata.google.internal/computeMetadata/v1/instance"
+	headers := map[string]string{"Metadata-Flavor": "Google"}
+
+	zonePath, err := metadataRequest(ctx, "GET", base+"/zone", headers)
+	if err != nil {
+		return "", "", "", err
+	}
+	machineTypePath, err := metadataRequest(ctx, "GET", base+"/machine-type", headers)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	zone := lastPathSegment(zonePath)
+	region := zone
+	if idx := strings.LastIndexByte(zone, '-'); idx > 0 {
+		region = zone[:idx]
+	}
+	return region, zone, lastPathSegment(machineTypePath), nil
+}
+
+func fetchAzureMetadata(ctx context.Context) (string, string, string, error) {
+	body, err := metadataRequest(ctx, "GET", "http://169.254.169.254/metadata/instance/compute?api-version=2021-02-01",
+		map[string]string{"Metadata": "true"})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var compute struct {
+		Location string `json:"location"`
+		Zone     string `json:"zone"`
+		VMSize   string `json:"vmSize"`
+	}
+	if err := json.Unmarshal([]byte(body), &compute); err != nil {
+		return "", "", "", fmt.Errorf("failed to parse Azure metadata: %w", err)
+	}
+	return compute.Location, compute.Zone, compute.VMSize, nil
+}
+
+// metadataRequest performs a metadata endpoint request and returns the trimmed response body.
+func metadataRequest(ctx context.Context, method, url string, headers map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("metadata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata request %s returned %s", url, resp.Status)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func lastPathSegment(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
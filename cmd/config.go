@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"perfana-cli/perfana_client"
+)
+
+// configCmd groups the config sub-commands
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the perfana-cli configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration and the connection to Perfana",
+	Long: `Loads the configuration, checks the required fields, verifies that an mTLS
+certificate/key pair and a custom CA certificate can be parsed and calls GET /api/health on the Perfana
+server. Prints a pass/fail line per check and exits 1 when any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		failed := 0
+		report := func(name string, err error) {
+			if err != nil {
+				failed++
+				fmt.Printf("   [FAIL]  %s: %v\n", name, err)
+				return
+			}
+			fmt.Printf("   [PASS]  %s\n", name)
+		}
+
+		fullConfig, err := loadFullConfig()
+		report("configuration file", err)
+		if err != nil {
+			os.Exit(1)
+		}
+		config := clientConfig(fullConfig)
+
+		// Report each problem found by Validate separately
+		validationErr := config.Validate()
+		if joined, ok := validationErr.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				report("configuration fields", err)
+			}
+		} else {
+			report("configuration fields", validationErr)
+		}
+
+		if config.MTLS.Enabled {
+			_, err := config.LoadClientKeyPair()
+			report("mTLS certificate/key pair", err)
+		}
+		if config.HasCACert() {
+			_, err := config.LoadRootCAs()
+			report("CA certificate", err)
+		}
+
+		if validationErr == nil {
+			client, err := perfana_client.NewClient(config)
+			if err == nil {
+				err = client.CheckHealth()
+			}
+			report("connection to "+config.ApiUrl, err)
+		} else {
+			fmt.Printf("   [SKIP]  connection to Perfana: the configuration is invalid\n")
+		}
+
+		fmt.Println()
+		if failed > 0 {
+			fmt.Printf("%d check(s) failed\n", failed)
+			os.Exit(1)
+		}
+		fmt.Println("All checks passed")
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the resolved configuration",
+	Long: `Loads the configuration file (--config, $PERFANA_CONFIG or the default location),
+applies the PERFANA_* environment variable overrides and the test settings used by
+the Perfana client, and prints the result with the path of the configuration file.
+The API key is shortened to its first and last four characters and an inline mTLS
+private key is replaced by <redacted>.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "yaml" && output != "json" {
+			fmt.Printf("Unknown output format %q (expected 'yaml' or 'json')\n", output)
+			os.Exit(1)
+		}
+
+		configPath, _, err := configFilePath()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+			// loadFullConfig fails below for a missing explicit path
+			configPath = ""
+		} else if absPath, err := filepath.Abs(configPath); err == nil {
+			configPath = absPath
+		}
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		resolved := *fullConfig
+		resolved.Perfana = clientConfig(fullConfig)
+		resolved.Perfana.ApiKey = redactSecret(resolved.Perfana.ApiKey)
+		if resolved.Perfana.MTLS.ClientKey != "" {
+			resolved.Perfana.MTLS.ClientKey = "<redacted>"
+		}
+
+		data, err := yaml.Marshal(&resolved)
+		if err != nil {
+			fmt.Printf("Error generating YAML: %v\n", err)
+			os.Exit(1)
+		}
+		if output == "yaml" {
+			if configPath == "" {
+				fmt.Println("# Configuration file: none, PERFANA_* environment variables only")
+			} else {
+				fmt.Printf("# Configuration file: %s\n", configPath)
+			}
+			fmt.Print(string(data))
+			return
+		}
+
+		// Convert through YAML so the JSON keys match the configuration file
+		var document map[string]interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			fmt.Printf("Error generating JSON: %v\n", err)
+			os.Exit(1)
+		}
+		data, err = json.MarshalIndent(map[string]interface{}{
+			"configFile": configPath,
+			"config":     document,
+		}, "", "  ")
+		if err != nil {
+			fmt.Printf("Error generating JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	},
+}
+
+// redactSecret keeps the first and last four characters of a secret. Secrets of eight
+// characters or less are masked completely.
+func redactSecret(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + "****" + secret[len(secret)-4:]
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	configShowCmd.Flags().String("output", "yaml", "Output format: yaml or json")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Show the estimated cost of a Perfana run",
+	Long: `The 'run cost' command prints the estimated infrastructure and storage cost of a test run.
+With --cost-threshold it exits 1 when the total cost exceeds the threshold, for use as a budget gate in CI.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		threshold, _ := cmd.Flags().GetFloat64("cost-threshold")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		cost, err := client.GetTestRunCost(testRunID)
+		if err != nil {
+			fmt.Printf("Error getting test run cost: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Infrastructure: %.2f %s\n", cost.InfrastructureCost, cost.Currency)
+		fmt.Printf("Storage:        %.2f %s\n", cost.StorageCost, cost.Currency)
+		fmt.Printf("Total:          %.2f %s\n", cost.TotalCost, cost.Currency)
+
+		if cmd.Flags().Changed("cost-threshold") && cost.TotalCost > threshold {
+			fmt.Printf("Total cost %.2f %s exceeds threshold %.2f\n", cost.TotalCost, cost.Currency, threshold)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(costCmd)
+
+	costCmd.Flags().String("testRunId", "", "ID of the test run")
+	costCmd.Flags().Float64("cost-threshold", 0, "Exit 1 when the total cost exceeds this amount")
+	_ = costCmd.MarkFlagRequired("testRunId")
+}
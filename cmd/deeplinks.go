@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// deeplinksCmd groups the deep link sub-commands
+var deeplinksCmd = &cobra.Command{
+	Use:   "deeplinks",
+	Short: "Manage deep links of a Perfana run",
+}
+
+var deeplinksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the deep links of a test run",
+	Long:  "The 'run deeplinks list' command prints the deep links currently attached to a test run.",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		output, _ := cmd.Flags().GetString("output")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		links, err := client.ListDeepLinks(testRunID)
+		if err != nil {
+			fmt.Printf("Error listing deep links: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(links, "", "  ")
+			if err != nil {
+				fmt.Printf("Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tTYPE\tPLUGIN\tURL")
+			for _, l := range links {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", l.Name, l.Type, l.PluginName, l.URL)
+			}
+			w.Flush()
+		default:
+			fmt.Printf("Unknown output format %q (expected 'table' or 'json')\n", output)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(deeplinksCmd)
+	deeplinksCmd.AddCommand(deeplinksListCmd)
+
+	deeplinksListCmd.Flags().String("testRunId", "", "ID of the test run")
+	deeplinksListCmd.Flags().String("output", "table", "Output format: table or json")
+	_ = deeplinksListCmd.MarkFlagRequired("testRunId")
+}
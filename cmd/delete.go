@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Perfana run",
+	Long: `The 'run delete' command permanently deletes a test run, e.g. one created by mistake.
+It asks for confirmation unless --yes is passed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if !yes {
+			fmt.Printf("Delete test run %s? This cannot be undone. [y/N] ", testRunID)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted")
+				return
+			}
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.DeleteTestRun(testRunID); err != nil {
+			var notFound *perfana_client.NotFoundError
+			if errors.As(err, &notFound) {
+				fmt.Printf("Test run not found: %s\n", testRunID)
+				os.Exit(1)
+			}
+			fmt.Printf("Error deleting test run: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Test run %s deleted\n", testRunID)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(deleteCmd)
+
+	deleteCmd.Flags().String("testRunId", "", "ID of the test run")
+	deleteCmd.Flags().Bool("yes", false, "Delete without asking for confirmation")
+	_ = deleteCmd.MarkFlagRequired("testRunId")
+}
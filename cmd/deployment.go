@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+)
+
+// deploymentCmd groups the deployment sub-commands
+var deploymentCmd = &cobra.Command{
+	Use:   "deployment",
+	Short: "Register deployments with Perfana",
+	Long:  "The 'deployment' command registers deployments, so Perfana dashboards can correlate them with test runs.",
+}
+
+var deploymentNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Register a deployment of a service",
+	Run: func(cmd *cobra.Command, args []string) {
+		version, _ := cmd.Flags().GetString("version")
+		service, _ := cmd.Flags().GetString("service")
+		environment, _ := cmd.Flags().GetString("environment")
+		changelogURL, _ := cmd.Flags().GetString("changelog-url")
+		commitSHA, _ := cmd.Flags().GetString("commit-sha")
+		deployedBy, _ := cmd.Flags().GetString("deployed-by")
+
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		config := clientConfig(fullConfig)
+
+		client, err := perfana_client.NewClient(config)
+		if err != nil {
+			fmt.Printf("Error initializing Perfana client: %v\n", err)
+			os.Exit(1)
+		}
+
+		if environment == "" {
+			environment = config.Environment
+		}
+
+		deploymentID, err := client.NotifyDeployment(perfana_client.DeploymentInfo{
+			Version:      version,
+			Service:      service,
+			Environment:  environment,
+			DeployedAt:   time.Now().UTC(),
+			DeployedBy:   deployedBy,
+			CommitSHA:    commitSHA,
+			ChangelogURL: changelogURL,
+		})
+		if err != nil {
+			fmt.Printf("Error notifying deployment: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(deploymentID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deploymentCmd)
+	deploymentCmd.AddCommand(deploymentNotifyCmd)
+
+	deploymentNotifyCmd.Flags().String("version", "", "Deployed version")
+	deploymentNotifyCmd.Flags().String("service", "", "Deployed service")
+	deploymentNotifyCmd.Flags().String("environment", "", "Environment the service was deployed to (default from the configuration file)")
+	deploymentNotifyCmd.Flags().String("changelog-url", "", "URL of the changelog or release notes of the version")
+	deploymentNotifyCmd.Flags().String("commit-sha", "", "Commit the version was built from")
+	deploymentNotifyCmd.Flags().String("deployed-by", "", "User or pipeline that deployed the version")
+	_ = deploymentNotifyCmd.MarkFlagRequired("version")
+	_ = deploymentNotifyCmd.MarkFlagRequired("service")
+}
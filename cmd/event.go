@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+	"perfana-cli/util"
+)
+
+var eventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "Send an event to a Perfana run",
+	Long: `The 'run event' command posts a named event to Perfana, e.g. to mark a deployment,
+a feature flag toggle or a configuration change on a running test without stopping it.
+The system under test, environment and workload default to the configuration file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		tags, _ := cmd.Flags().GetString("tags")
+		systemUnderTest, _ := cmd.Flags().GetString("systemUnderTest")
+		testEnvironment, _ := cmd.Flags().GetString("testEnvironment")
+
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		config := clientConfig(fullConfig)
+
+		client, err := perfana_client.NewClient(config)
+		if err != nil {
+			fmt.Printf("Error initializing Perfana client: %v\n", err)
+			os.Exit(1)
+		}
+
+		if systemUnderTest == "" {
+			systemUnderTest = config.SystemUnderTest
+		}
+		if testEnvironment == "" {
+			testEnvironment = config.Environment
+		}
+
+		ctx, cancel := requestContext()
+		defer cancel()
+		result, err := client.SendPerfanaEvent(ctx, perfana_client.PerfanaEvent{
+			TestRunID:       testRunID,
+			SystemUnderTest: systemUnderTest,
+			TestEnvironment: testEnvironment,
+			Workload:        config.Workload,
+			Title:           title,
+			Description:     description,
+			Tags:            util.ParseTagsString(tags, ","),
+		})
+		if err != nil {
+			fmt.Printf("Error sending event: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(result.Message)
+		if result.EventID != "" {
+			fmt.Printf("Event ID: %s\n", result.EventID)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(eventCmd)
+
+	eventCmd.Flags().String("testRunId", "", "ID of the test run")
+	eventCmd.Flags().String("title", "", "Title of the event")
+	eventCmd.Flags().String("description", "", "Description of the event")
+	eventCmd.Flags().String("tags", "", "Comma-separated list of tags")
+	eventCmd.Flags().String("systemUnderTest", "", "System under test (default from the configuration file)")
+	eventCmd.Flags().String("testEnvironment", "", "Test environment (default from the configuration file)")
+	_ = eventCmd.MarkFlagRequired("title")
+}
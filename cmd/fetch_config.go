@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"perfana-cli/perfana_client"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var fetchConfigCmd = &cobra.Command{
+	Use:   "fetch-config",
+	Short: "Download the test configuration stored in Perfana",
+	Long: `The 'run fetch-config' command downloads the variables, deep links, tags and SLAs stored
+in Perfana for a system under test, workload and environment, and saves them as YAML.
+Defaults for the selection are taken from the configuration file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		config := clientConfig(fullConfig)
+
+		systemUnderTest, _ := cmd.Flags().GetString("systemUnderTest")
+		workload, _ := cmd.Flags().GetString("workload")
+		environment, _ := cmd.Flags().GetString("environment")
+		output, _ := cmd.Flags().GetString("output")
+		if systemUnderTest == "" {
+			systemUnderTest = config.SystemUnderTest
+		}
+		if workload == "" {
+			workload = config.Workload
+		}
+		if environment == "" {
+			environment = config.Environment
+		}
+
+		client, err := perfana_client.NewClient(config)
+		if err != nil {
+			fmt.Printf("Error initializing Perfana client: %v\n", err)
+			os.Exit(1)
+		}
+
+		serverConfig, err := client.FetchConfig(systemUnderTest, workload, environment)
+		if err != nil {
+			fmt.Printf("Error fetching config: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := yaml.Marshal(serverConfig)
+		if err != nil {
+			fmt.Printf("Error generating YAML: %v\n", err)
+			os.Exit(1)
+		}
+
+		if output == "" {
+			fmt.Print(string(data))
+			return
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", output, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Server config written to %s\n", output)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(fetchConfigCmd)
+
+	fetchConfigCmd.Flags().String("systemUnderTest", "", "System under test (defaults to the configured value)")
+	fetchConfigCmd.Flags().String("workload", "", "Workload (defaults to the configured value)")
+	fetchConfigCmd.Flags().String("environment", "", "Environment (defaults to the configured value)")
+	fetchConfigCmd.Flags().String("output", "", "File to write the YAML to (default stdout)")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Perfana test runs",
+	Long:  "The 'run list' command lists test runs filtered by system under test, environment, workload and date range.",
+	Run: func(cmd *cobra.Command, args []string) {
+		sut, _ := cmd.Flags().GetString("systemUnderTest")
+		environment, _ := cmd.Flags().GetString("environment")
+		workload, _ := cmd.Flags().GetString("workload")
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+		limit, _ := cmd.Flags().GetInt("limit")
+		output, _ := cmd.Flags().GetString("output")
+
+		from, err := parseListTime(fromFlag)
+		if err != nil {
+			fmt.Printf("Invalid --from: %v\n", err)
+			os.Exit(1)
+		}
+		to, err := parseListTime(toFlag)
+		if err != nil {
+			fmt.Printf("Invalid --to: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		testRuns, err := client.ListTestRuns(perfana_client.TestRunFilter{
+			SystemUnderTest: sut,
+			Environment:     environment,
+			Workload:        workload,
+			From:            from,
+			To:              to,
+			Limit:           limit,
+		})
+		if err != nil {
+			fmt.Printf("Error listing test runs: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(testRuns, "", "  ")
+			if err != nil {
+				fmt.Printf("Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TEST RUN ID\tSYSTEM UNDER TEST\tENVIRONMENT\tWORKLOAD\tSTATUS\tSTART")
+			for _, r := range testRuns {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					r.TestRunID, r.SystemUnderTest, r.TestEnvironment, r.Workload, r.Status, r.Start.Format(time.RFC3339))
+			}
+			w.Flush()
+		default:
+			fmt.Printf("Unknown output format %q (expected 'table' or 'json')\n", output)
+			os.Exit(1)
+		}
+	},
+}
+
+// parseListTime parses an RFC 3339 timestamp or a YYYY-MM-DD date. An empty value is the zero time.
+func parseListTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func init() {
+	runCmd.AddCommand(listCmd)
+
+	listCmd.Flags().String("systemUnderTest", "", "Only list test runs of this system under test")
+	listCmd.Flags().String("environment", "", "Only list test runs in this test environment")
+	listCmd.Flags().String("workload", "", "Only list test runs with this workload")
+	listCmd.Flags().String("from", "", "Only list test runs started at or after this time (RFC 3339 or YYYY-MM-DD)")
+	listCmd.Flags().String("to", "", "Only list test runs started before this time (RFC 3339 or YYYY-MM-DD)")
+	listCmd.Flags().Int("limit", 50, "Maximum number of test runs to list (0 lists all)")
+	listCmd.Flags().String("output", "table", "Output format: table or json")
+}
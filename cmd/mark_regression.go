@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var markRegressionCmd = &cobra.Command{
+	Use:   "mark-regression",
+	Short: "Mark a Perfana run as a regression",
+	Long:  "The 'run mark-regression' command marks a test run as a regression, optionally on specific metrics and with a severity.",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		metrics, _ := cmd.Flags().GetStringSlice("metric")
+		severity, _ := cmd.Flags().GetString("regression-severity")
+
+		severity = strings.ToUpper(severity)
+		switch severity {
+		case "", "LOW", "MEDIUM", "HIGH":
+		default:
+			fmt.Printf("Invalid regression severity %q (expected LOW, MEDIUM or HIGH)\n", severity)
+			os.Exit(1)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.TagTestRunAsRegressionWithSeverity(testRunID, metrics, severity); err != nil {
+			fmt.Printf("Error marking regression: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Test run %s marked as regression\n", testRunID)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(markRegressionCmd)
+
+	markRegressionCmd.Flags().String("testRunId", "", "ID of the test run")
+	markRegressionCmd.Flags().StringSlice("metric", []string{}, "Metric that regressed (repeatable)")
+	markRegressionCmd.Flags().String("regression-severity", "", "Severity of the regression: LOW, MEDIUM or HIGH")
+	_ = markRegressionCmd.MarkFlagRequired("testRunId")
+}
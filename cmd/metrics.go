@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// metricsCmd groups the metrics sub-commands
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Manage metrics of a Perfana run",
+}
+
+var metricsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Download the raw metrics of a test run",
+	Long: `The 'run metrics export' command downloads the raw metrics of a test run as CSV or JSON
+for post-processing in external tools. Without --output the metrics are written to stdout.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		if format != "csv" && format != "json" {
+			fmt.Printf("Unknown format %q (expected 'csv' or 'json')\n", format)
+			os.Exit(1)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		body, err := client.ExportMetrics(testRunID, format)
+		if err != nil {
+			fmt.Printf("Error exporting metrics: %v\n", err)
+			os.Exit(1)
+		}
+		defer body.Close()
+
+		if output == "" {
+			if _, err := io.Copy(os.Stdout, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error downloading metrics: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Printf("Error creating %s: %v\n", output, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		progress := &downloadProgress{}
+		written, err := io.Copy(file, io.TeeReader(body, progress))
+		progress.done()
+		if err != nil {
+			fmt.Printf("Error downloading metrics: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Metrics exported to %s (%s)\n", output, formatBytes(written))
+	},
+}
+
+// downloadProgressStep is the number of bytes between progress updates.
+const downloadProgressStep = 1 << 20
+
+// downloadProgress reports the number of bytes downloaded on stderr.
+type downloadProgress struct {
+	total    int64
+	reported int64
+}
+
+func (p *downloadProgress) Write(b []byte) (int, error) {
+	p.total += int64(len(b))
+	if p.total-p.reported >= downloadProgressStep {
+		p.reported = p.total
+		fmt.Fprintf(os.Stderr, "\rDownloaded %s", formatBytes(p.total))
+	}
+	return len(b), nil
+}
+
+// done ends the progress line, if any progress was reported.
+func (p *downloadProgress) done() {
+	if p.reported > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// formatBytes formats a byte count as B, KB or MB.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func init() {
+	runCmd.AddCommand(metricsCmd)
+	metricsCmd.AddCommand(metricsExportCmd)
+
+	metricsExportCmd.Flags().String("testRunId", "", "ID of the test run")
+	metricsExportCmd.Flags().String("format", "csv", "Export format: csv or json")
+	metricsExportCmd.Flags().String("output", "", "File to write the metrics to (default: stdout)")
+	_ = metricsExportCmd.MarkFlagRequired("testRunId")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause a Perfana run",
+	Long: `The 'run pause' command pauses a running test, e.g. to exclude a maintenance window
+from the timeline. A running 'run start' stops sending keep-alives until the run is resumed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.PauseTestRun(testRunID); err != nil {
+			fmt.Printf("Error pausing test run: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Test run %s paused\n", testRunID)
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused Perfana run",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.ResumePausedTestRun(testRunID); err != nil {
+			fmt.Printf("Error resuming test run: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Test run %s resumed\n", testRunID)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(pauseCmd, resumeCmd)
+
+	for _, c := range []*cobra.Command{pauseCmd, resumeCmd} {
+		c.Flags().String("testRunId", "", "ID of the test run")
+		_ = c.MarkFlagRequired("testRunId")
+	}
+}
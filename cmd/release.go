@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// releaseCmd groups the release sub-commands
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Manage Perfana releases",
+	Long:  "The 'release' command correlates test runs (e.g. smoke, load, soak) with a software release.",
+}
+
+var releaseCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a release for a set of test runs",
+	Run: func(cmd *cobra.Command, args []string) {
+		version, _ := cmd.Flags().GetString("version")
+		sut, _ := cmd.Flags().GetString("sut")
+		testRunIDs, _ := cmd.Flags().GetStringArray("test-run")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		releaseID, err := client.CreateRelease(version, sut, testRunIDs)
+		if err != nil {
+			fmt.Printf("Error creating release: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(releaseID)
+	},
+}
+
+var releaseListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List releases",
+	Run: func(cmd *cobra.Command, args []string) {
+		sut, _ := cmd.Flags().GetString("sut")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		releases, err := client.ListReleases(sut)
+		if err != nil {
+			fmt.Printf("Error listing releases: %v\n", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tVERSION\tSYSTEM UNDER TEST\tCREATED\tTEST RUNS")
+		for _, r := range releases {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.ID, r.Version, r.SystemUnderTest, r.CreatedAt.Format(time.RFC3339), strings.Join(r.TestRunIDs, ","))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(releaseCreateCmd, releaseListCmd)
+
+	releaseCreateCmd.Flags().String("version", "", "Version of the release")
+	releaseCreateCmd.Flags().String("sut", "", "System under test of the release")
+	releaseCreateCmd.Flags().StringArray("test-run", nil, "ID of a test run belonging to the release (can be repeated)")
+	_ = releaseCreateCmd.MarkFlagRequired("version")
+	_ = releaseCreateCmd.MarkFlagRequired("sut")
+
+	releaseListCmd.Flags().String("sut", "", "Only list releases of this system under test")
+}
@@ -17,7 +17,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"perfana-cli/logger"
+	"perfana-cli/util"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,6 +28,21 @@ import (
 
 var cfgFile string
 
+// logLevel and logFormat configure the diagnostics written to stderr.
+var (
+	logLevel  string
+	logFormat string
+)
+
+// noColor disables ANSI formatting in log messages and progress output.
+var noColor bool
+
+// colorDisabled reports whether ANSI formatting is disabled by --no-color or
+// a non-empty NO_COLOR environment variable (https://no-color.org).
+func colorDisabled() bool {
+	return noColor || os.Getenv("NO_COLOR") != ""
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "perfana-cli",
@@ -51,7 +69,10 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.perfana-cli/perfana.yaml)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format: text or json")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable ANSI colour and formatting codes in output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $PERFANA_CONFIG, then $HOME/.perfana-cli/perfana.yaml)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -60,6 +81,12 @@ func init() {
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	var logOutput io.Writer = os.Stderr
+	if colorDisabled() {
+		logOutput = util.NewAnsiWriter(logOutput, true)
+	}
+	cobra.CheckErr(logger.Configure(logOutput, logLevel, logFormat))
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search Perfana test runs",
+	Long:  "The 'run search' command searches test runs by free text, tags and status.",
+	Run: func(cmd *cobra.Command, args []string) {
+		text, _ := cmd.Flags().GetString("query")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		status, _ := cmd.Flags().GetString("status")
+		page, _ := cmd.Flags().GetInt("page")
+		output, _ := cmd.Flags().GetString("output")
+
+		if status != "" && status != "active" && status != "completed" {
+			fmt.Printf("Invalid status %q (expected 'active' or 'completed')\n", status)
+			os.Exit(1)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := client.SearchTestRuns(perfana_client.TestRunQuery{
+			TextSearch: text,
+			Tags:       tags,
+			Status:     status,
+			Page:       page,
+		})
+		if err != nil {
+			fmt.Printf("Error searching test runs: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				fmt.Printf("Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TEST RUN ID\tSYSTEM UNDER TEST\tENVIRONMENT\tWORKLOAD\tSTATUS\tSTART")
+			for _, r := range result.Items {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					r.TestRunID, r.SystemUnderTest, r.TestEnvironment, r.Workload, r.Status, r.Start.Format(time.RFC3339))
+			}
+			w.Flush()
+			fmt.Printf("\n%d of %d test runs (page %d)\n", len(result.Items), result.Total, result.Page)
+		default:
+			fmt.Printf("Unknown output format %q (expected 'table' or 'json')\n", output)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().String("query", "", "Free text to search for")
+	searchCmd.Flags().StringArray("tag", nil, "Only return test runs with this tag (can be repeated)")
+	searchCmd.Flags().String("status", "", "Only return test runs with this status: active or completed")
+	searchCmd.Flags().Int("page", 0, "Page of the search result to return")
+	searchCmd.Flags().String("output", "table", "Output format: table or json")
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// parseExtendSignal returns the signal named by --timeout-extend-signal.
+func parseExtendSignal(name string) (os.Signal, error) {
+	switch strings.ToUpper(name) {
+	case "SIGUSR1", "USR1":
+		return syscall.SIGUSR1, nil
+	case "SIGUSR2", "USR2":
+		return syscall.SIGUSR2, nil
+	default:
+		return nil, fmt.Errorf("unsupported signal %q (expected SIGUSR1 or SIGUSR2)", name)
+	}
+}
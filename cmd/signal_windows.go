@@ -0,0 +1,13 @@
+//go:build windows
+
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+// parseExtendSignal returns an error: SIGUSR1 and SIGUSR2 do not exist on Windows.
+func parseExtendSignal(name string) (os.Signal, error) {
+	return nil, errors.New("--timeout-extend-signal is not supported on Windows")
+}
@@ -16,14 +16,19 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"perfana-cli/logger"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"regexp"
 	"strings"
+	"syscall"
+	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 	"perfana-cli/events"
 	"perfana-cli/perfana_client"
 	"perfana-cli/scheduler"
@@ -91,6 +96,47 @@ var (
 	buildResultsUrl     string
 	variablesFlag       []string
 	deepLinksFlag       []string
+
+	reportDeepLinkOnComplete bool
+	exportOnComplete         string
+	writeJUnitXML            string
+	assertTestRunIDFormat    string
+	summaryOnComplete        bool
+	failOnAssertions         bool
+	startOutput              string
+	cloudProvider            string
+	compressVariables        int
+	useServerTime            bool
+	structuredStdout         bool
+	environmentFile          string
+	injectFailure            float64
+	allowFaultInjection      bool
+	tagsSeparator            string
+	maxAnnotationLength      int
+	annotationMaxLines       int
+	annotationsTemplateFile  string
+	heartbeatURL             string
+	heartbeatFailureURL      string
+	noCompleteOnTimeout      bool
+	eventOnRampUpComplete    string
+	traceID                  string
+	traceIDEnv               string
+	slackWebhookURL          string
+	slackMessageTemplate     string
+	saveTestRunURL           string
+	outputFile               string
+	outputFileFormat         string
+	abortFile                string
+	abortFilePollInterval    string
+	noSignalHandler          bool
+	rateLimitEvents          string
+	eventQueueSize           int
+	progressBar              bool
+	printKeepAliveCount      bool
+	uploadTestPlan           string
+	testPlanName             string
+	eventSchemaVersion       int
+	keepAliveIntervalFlag    string
 )
 
 // startCmd represents the start command
@@ -101,50 +147,43 @@ var startCmd = &cobra.Command{
 orchestration. It runs BeforeTest → StartTest → KeepAlive loop → CheckResults → AfterTest.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// Load the configuration file
-		configPath := cfgFile
-		if configPath == "" {
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				fmt.Println("Error finding home directory:", err)
-				return
-			}
-			configPath = filepath.Join(homeDir, ".perfana-cli", "perfana.yaml")
+		// With --attach the session runs in a background process started with the same flags
+		if attach && !isAttachedProcess() {
+			runAttached()
+			return
 		}
 
-		// Also check for ./perfana.yaml in current directory
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			if _, err2 := os.Stat("perfana.yaml"); err2 == nil {
-				configPath = "perfana.yaml"
+		// Environment variables from the file are visible to the config, hooks and commands
+		if environmentFile != "" {
+			names, err := util.LoadEnvFile(environmentFile)
+			if err != nil {
+				fmt.Printf("Error reading environment file: %v\n", err)
+				os.Exit(1)
 			}
+			logger.Debug("loaded environment file", "file", environmentFile, "variables", strings.Join(names, ","))
 		}
 
-		file, err := os.ReadFile(configPath)
+		// Load the configuration file
+		fullConfig, err := loadFullConfig()
 		if err != nil {
-			fmt.Printf("Error reading configuration file: %v\n", err)
-			return
-		}
-
-		// Expand environment variables in YAML content
-		expandedContent := os.ExpandEnv(string(file))
-
-		// Parse the full YAML configuration
-		var fullConfig FullConfig
-		if err := yaml.Unmarshal([]byte(expandedContent), &fullConfig); err != nil {
-			fmt.Printf("Error parsing configuration file: %v\n", err)
+			fmt.Printf("%v\n", err)
 			return
 		}
 
 		// Apply test config to perfana client config if not set directly
-		config := fullConfig.Perfana
-		if config.SystemUnderTest == "" {
-			config.SystemUnderTest = fullConfig.Test.SystemUnderTest
+		config := clientConfig(fullConfig)
+		if eventSchemaVersion > 0 {
+			config.EventSchemaVersion = eventSchemaVersion
 		}
-		if config.Environment == "" {
-			config.Environment = fullConfig.Test.Environment
-		}
-		if config.Workload == "" {
-			config.Workload = fullConfig.Test.Workload
+
+		// Point the client at a local mock server when simulating a run
+		if noop {
+			noopServer := startNoopServer(config)
+			defer noopServer.Close()
+			config.ApiUrl = noopServer.URL
+			config.AppUrl = noopServer.URL
+			config.MTLS.Enabled = false
+			logger.Info("noop mode: using local mock server", "url", noopServer.URL)
 		}
 
 		// CLI flags override YAML values
@@ -181,6 +220,62 @@ orchestration. It runs BeforeTest → StartTest → KeepAlive loop → CheckResu
 			}
 		}
 
+		// Make the k6 run parameters visible in Perfana
+		if k6EnvForward {
+			for k, v := range k6EnvVariables() {
+				variables[k] = v
+			}
+		}
+
+		// Hash the configured workload before run-specific variables are added
+		configHash := ""
+		if autoTagWorkloadHash {
+			configHash = workloadConfigHash(config.Workload, config.Environment, effectiveVersion, variables)
+		}
+
+		// Link the run to an external distributed trace
+		effectiveTraceID := traceID
+		if effectiveTraceID == "" && traceIDEnv != "" {
+			effectiveTraceID = os.Getenv(traceIDEnv)
+		}
+		if effectiveTraceID != "" {
+			variables["traceId"] = effectiveTraceID
+			config.TraceID = effectiveTraceID
+		}
+
+		// Tag the run with cloud instance metadata; failures must not block the test
+		if cloudProvider != "" && dryRun {
+			logger.Info("dry run: skipping cloud metadata", "provider", cloudProvider)
+		} else if cloudProvider != "" {
+			cloudVariables, err := fetchCloudMetadata(cloudProvider)
+			if err != nil {
+				logger.Warn("failed to fetch cloud metadata", "provider", cloudProvider, "err", err)
+			}
+			for k, v := range cloudVariables {
+				variables[k] = v
+			}
+		}
+
+		// Compress large variable values; the placeholder gets a _b64gz suffix
+		if compressVariables > 0 {
+			large := make(map[string]string)
+			for k, v := range variables {
+				if len(v) > compressVariables {
+					large[k] = v
+				}
+			}
+			for k, v := range large {
+				compressed, err := util.GzipBase64(v)
+				if err != nil {
+					fmt.Printf("Error compressing variable %s: %v\n", k, err)
+					os.Exit(1)
+				}
+				delete(variables, k)
+				variables[k+"_b64gz"] = compressed
+				logger.Info("compressed variable", "placeholder", k+"_b64gz", "originalBytes", len(v), "compressedBytes", len(compressed))
+			}
+		}
+
 		// Parse durations
 		analysisStartOffsetSec, err := util.ParseISODurationToSeconds(effectiveAnalysisStartOffset)
 		if err != nil {
@@ -197,32 +292,70 @@ orchestration. It runs BeforeTest → StartTest → KeepAlive loop → CheckResu
 		totalDurationSec := analysisStartOffsetSec + constantLoadSec
 		logger.Info("starting test run", "durationSec", totalDurationSec, "analysisStartOffsetSec", analysisStartOffsetSec, "constantLoadSec", constantLoadSec)
 
-		// Initialize the Perfana client
-		client, err := perfana_client.NewClient(config)
-		if err != nil {
-			fmt.Printf("Error initializing Perfana client: %v\n", err)
-			return
+		// Initialize the Perfana client; a dry run makes no HTTP calls, so it needs none
+		var client *perfana_client.PerfanaClient
+		if !dryRun {
+			client, err = perfana_client.NewClient(config)
+			if err != nil {
+				fmt.Printf("Error initializing Perfana client: %v\n", err)
+				return
+			}
 		}
 
 		// Build tag list from YAML + CLI
 		tagList := fullConfig.Test.Tags
 		if tags != "" {
-			for _, t := range strings.Split(tags, ",") {
-				t = strings.TrimSpace(t)
-				if t != "" {
-					tagList = append(tagList, t)
-				}
-			}
+			tagList = append(tagList, util.ParseTagsString(tags, tagsSeparator)...)
+		}
+		if configHash != "" {
+			tagList = append(tagList, "config-hash="+configHash)
+		}
+		if tagVersionComponents {
+			tagList = append(tagList, versionComponentTags(effectiveVersion)...)
 		}
 
-		// Resolve annotation from CLI flag or YAML
+		// Resolve annotation from CLI flag or YAML; "-" reads it from stdin
 		effectiveAnnotation := fullConfig.Test.Annotations
-		if annotation != "" {
+		if annotation == "-" {
+			effectiveAnnotation, err = readStdinAnnotation()
+			if err != nil {
+				fmt.Printf("Error reading annotation from stdin: %v\n", err)
+				os.Exit(1)
+			}
+		} else if annotation != "" {
 			effectiveAnnotation = annotation
 		}
 
+		effectiveAnnotation = limitAnnotationLines(effectiveAnnotation, annotationMaxLines)
+		effectiveAnnotation = truncateAnnotation(effectiveAnnotation, maxAnnotationLength)
+
 		// Resolve buildResultsUrl from CLI flag or YAML
 		effectiveBuildResultsUrl := buildResultsUrl
+		if effectiveBuildResultsUrl == "" && ciBuildURLFromEnv {
+			effectiveBuildResultsUrl, err = ciBuildURL(ciSystem)
+			if err != nil {
+				fmt.Printf("Error deriving CI build results URL: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Generated deep links anchor their time range to the run start
+		deepLinks := fullConfig.Test.DeepLinks
+		runStart := time.Now()
+		toolDeepLinks, err := buildToolDeepLinks(runStart, runStart.Add(time.Duration(totalDurationSec)*time.Second))
+		if err != nil {
+			fmt.Printf("Error building deep links: %v\n", err)
+			os.Exit(1)
+		}
+		deepLinks = append(deepLinks, toolDeepLinks...)
+		for _, value := range deepLinksFlag {
+			link, err := parseDeepLinkFlag(value)
+			if err != nil {
+				fmt.Printf("Error parsing deep link: %v\n", err)
+				os.Exit(1)
+			}
+			deepLinks = append(deepLinks, link)
+		}
 
 		// Build test context
 		testCtx := scheduler.TestContext{
@@ -236,10 +369,19 @@ orchestration. It runs BeforeTest → StartTest → KeepAlive loop → CheckResu
 			AnalysisStartOffset: analysisStartOffsetSec,
 			Duration:            constantLoadSec,
 			BuildResultsUrl:     effectiveBuildResultsUrl,
-			DeepLinks:           fullConfig.Test.DeepLinks,
+			DeepLinks:           deepLinks,
 			Client:              client,
 		}
 
+		// Print the start event instead of running the test
+		if dryRun {
+			if err := printDryRunMessage(config, testCtx); err != nil {
+				fmt.Printf("Error building the test event: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Parse events from YAML config
 		var eventList []scheduler.Event
 		for _, ec := range fullConfig.Events {
@@ -282,27 +424,266 @@ orchestration. It runs BeforeTest → StartTest → KeepAlive loop → CheckResu
 			logger.Info("schedule parsed", "entries", len(scheduleEntries))
 		}
 
+		// --keepAliveInterval, then perfana.keepAliveInterval, then scheduler.keepAliveIntervalSeconds
 		keepAliveInterval := fullConfig.Scheduler.KeepAliveIntervalSeconds
+		effectiveKeepAliveInterval := config.KeepAliveInterval
+		if keepAliveIntervalFlag != "" {
+			effectiveKeepAliveInterval = keepAliveIntervalFlag
+		}
+		if effectiveKeepAliveInterval != "" {
+			keepAliveInterval, err = util.ParseSecondsOrISODuration(effectiveKeepAliveInterval)
+			if err != nil {
+				fmt.Printf("Error parsing keepAliveInterval: %v\n", err)
+				return
+			}
+		}
 		if keepAliveInterval <= 0 {
 			keepAliveInterval = 30
 		}
 
+		var eventRateInterval time.Duration
+		if rateLimitEvents != "" {
+			eventRateInterval, err = util.ParseRate(rateLimitEvents)
+			if err != nil {
+				fmt.Printf("Invalid --rate-limit-events: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var slas []perfana_client.SLADefinition
+		var slaInterval time.Duration
+		if abortOnSLABreach {
+			if slaFile == "" {
+				fmt.Println("--abort-on-sla-breach requires --sla-file")
+				os.Exit(1)
+			}
+			slas, err = loadSLAFile(slaFile)
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				os.Exit(1)
+			}
+			slaInterval, err = util.ParseISODurationToTimeDuration(slaPollInterval)
+			if err != nil {
+				fmt.Printf("Error parsing sla-poll-interval: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var extendSignal os.Signal
+		var extendStep time.Duration
+		if timeoutExtendSignal != "" {
+			extendSignal, err = parseExtendSignal(timeoutExtendSignal)
+			if err != nil {
+				fmt.Printf("Invalid --timeout-extend-signal: %v\n", err)
+				os.Exit(1)
+			}
+			extendStep, err = util.ParseISODurationToTimeDuration(timeoutExtendStep)
+			if err != nil {
+				fmt.Printf("Error parsing timeout-extend-step: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var abortFileInterval time.Duration
+		if abortFile != "" {
+			abortFileInterval, err = util.ParseISODurationToTimeDuration(abortFilePollInterval)
+			if err != nil {
+				fmt.Printf("Error parsing abort-file-poll-interval: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var checkpointEvery time.Duration
+		var checkpointTemplate *template.Template
+		if checkpointInterval != "" {
+			checkpointEvery, err = util.ParseISODurationToTimeDuration(checkpointInterval)
+			if err != nil {
+				fmt.Printf("Error parsing checkpoint-interval: %v\n", err)
+				os.Exit(1)
+			}
+			checkpointTemplate, err = template.New("checkpoint").Parse(checkpointNameTemplate)
+			if err != nil {
+				fmt.Printf("Invalid --checkpoint-name-template: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var testRunIDFormat *regexp.Regexp
+		if assertTestRunIDFormat != "" {
+			testRunIDFormat, err = regexp.Compile(assertTestRunIDFormat)
+			if err != nil {
+				fmt.Printf("Error parsing --assert-test-run-id-format: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if startOutput != "text" && startOutput != "json" {
+			fmt.Printf("Unknown output format %q (expected 'text' or 'json')\n", startOutput)
+			os.Exit(1)
+		}
+
+		if injectFailure != 0 {
+			if !allowFaultInjection {
+				fmt.Println("--inject-failure requires --allow-fault-injection")
+				os.Exit(1)
+			}
+			if injectFailure < 0 || injectFailure > 1 {
+				fmt.Printf("--inject-failure must be between 0.0 and 1.0 (got %g)\n", injectFailure)
+				os.Exit(1)
+			}
+			logger.Warn("fault injection enabled", "keepAliveFailureRate", injectFailure)
+		}
+
+		var postStartCheck func(scheduler.TestContext) error
+		if waitForGrafanaAnnotation {
+			if grafanaURL == "" {
+				fmt.Println("--wait-for-grafana-annotation requires --grafana-url")
+				os.Exit(1)
+			}
+			timeout, err := util.ParseISODurationToTimeDuration(grafanaAnnotationTimeout)
+			if err != nil {
+				fmt.Printf("Error parsing grafana-annotation-timeout: %v\n", err)
+				os.Exit(1)
+			}
+			postStartCheck = func(ctx scheduler.TestContext) error {
+				return waitForGrafanaTestRunAnnotation(grafanaURL, grafanaAPIKey, ctx.TestRunID, timeout)
+			}
+		}
+
+		// The summary is shown by default in interactive terminals only
+		if !cmd.Flags().Changed("summary-on-complete") {
+			summaryOnComplete = util.IsTerminal(os.Stdout)
+		}
+
+		// The progress bar is shown by default in interactive terminals, never with machine-readable output
+		if !cmd.Flags().Changed("progress-bar") {
+			progressBar = util.IsTerminal(os.Stdout)
+		}
+		if startOutput == "json" || structuredStdout {
+			progressBar = false
+		}
+
+		if outputFile == "" {
+			outputFile = os.Getenv("PERFANA_OUTPUT_FILE")
+		}
+		if outputFileFormat != "text" && outputFileFormat != "json" {
+			fmt.Printf("Unknown output file format %q (expected 'text' or 'json')\n", outputFileFormat)
+			os.Exit(1)
+		}
+
+		// SIGINT/SIGTERM cancel the pre-init waits and the Perfana requests in flight, e.g. a slow Init with retries
+		runCtx := context.Background()
+		if !noSignalHandler {
+			var stop context.CancelFunc
+			runCtx, stop = signal.NotifyContext(runCtx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+		}
+
 		// Create the event scheduler
 		eventScheduler := &scheduler.EventScheduler{
 			Client:               client,
+			Context:              runCtx,
 			Events:               eventList,
 			ScheduleEntries:      scheduleEntries,
 			KeepAliveIntervalSec: keepAliveInterval,
 			TestDurationSec:      totalDurationSec,
 			TestContext:          testCtx,
 			FailOnError:          fullConfig.Scheduler.FailOnError,
+
+			ReportDeepLinkOnComplete: reportDeepLinkOnComplete,
+			ExportFile:               exportOnComplete,
+			JUnitFile:                writeJUnitXML,
+			TestRunURLFile:           saveTestRunURL,
+			OutputFile:               outputFile,
+			OutputFileFormat:         outputFileFormat,
+			TestPlanFile:             uploadTestPlan,
+			TestPlanName:             testPlanName,
+			TestRunIDFormat:          testRunIDFormat,
+			PostStartCheck:           postStartCheck,
+			RampUpCompleteEventTitle: eventOnRampUpComplete,
+			CheckpointInterval:       checkpointEvery,
+			CheckpointNameTemplate:   checkpointTemplate,
+			NoCompleteOnTimeout:      noCompleteOnTimeout || attach,
+			StopOnExternalCompletion: attach,
+			UseServerTime:            useServerTime,
+			KeepAliveFailureRate:     injectFailure,
+			HeartbeatURL:             heartbeatURL,
+			HeartbeatFailureURL:      heartbeatFailureURL,
+			HeartbeatClient:          hookHTTPClient(client),
+			StructuredStdout:         structuredStdout,
+			SlackWebhookURL:          slackWebhookURL,
+			SlackMessageTemplate:     slackMessageTemplate,
+			TraceID:                  effectiveTraceID,
+			NoSignalHandler:          noSignalHandler,
+			AbortFile:                abortFile,
+			AbortFilePollInterval:    abortFileInterval,
+			EventRateInterval:        eventRateInterval,
+			EventQueueSize:           eventQueueSize,
+			SLAs:                     slas,
+			SLAPollInterval:          slaInterval,
+			AnnotationsTemplateFile:  annotationsTemplateFile,
+			TimeoutExtendSignal:      extendSignal,
+			TimeoutExtendStep:        extendStep,
+			ProgressBar:              progressBar,
+			NoColor:                  colorDisabled(),
+			PrintKeepAliveCount:      printKeepAliveCount,
+			SummaryOnComplete:        summaryOnComplete,
+			IgnoreAssertionFailures:  !failOnAssertions,
+			SummaryFormat:            startOutput,
 		}
 
 		logger.Info("scheduler configured", "events", len(eventList), "scheduleEntries", len(scheduleEntries), "keepAliveIntervalSec", keepAliveInterval)
 
+		// Wait for the system under test to signal it is ready
+		if pollForStartTrigger != "" {
+			interval, err := util.ParseISODurationToTimeDuration(pollInterval)
+			if err != nil {
+				fmt.Printf("Error parsing poll-interval: %v\n", err)
+				os.Exit(1)
+			}
+			timeout, err := util.ParseISODurationToTimeDuration(pollTimeout)
+			if err != nil {
+				fmt.Printf("Error parsing poll-timeout: %v\n", err)
+				os.Exit(1)
+			}
+			if err := waitForStartTrigger(runCtx, pollForStartTrigger, interval, timeout); err != nil {
+				fmt.Printf("Error waiting for start trigger: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Give the infrastructure time to be provisioned
+		if timeoutBeforeInit != "" {
+			wait, err := util.ParseISODurationToTimeDuration(timeoutBeforeInit)
+			if err != nil {
+				fmt.Printf("Error parsing timeout-before-init: %v\n", err)
+				os.Exit(1)
+			}
+			if err := waitBeforeInit(runCtx, wait); err != nil {
+				fmt.Printf("%v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Wait for the system under test to accept connections
+		if len(awaitPorts) > 0 {
+			timeout, err := util.ParseISODurationToTimeDuration(awaitTimeout)
+			if err != nil {
+				fmt.Printf("Error parsing await-timeout: %v\n", err)
+				os.Exit(1)
+			}
+			if err := waitForPorts(runCtx, awaitPorts, timeout); err != nil {
+				fmt.Printf("Error waiting for ports: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Run the full lifecycle
 		if err := eventScheduler.Run(); err != nil {
 			fmt.Printf("Test run failed: %v\n", err)
+			if errors.Is(err, scheduler.ErrSLABreached) {
+				os.Exit(2)
+			}
 			os.Exit(1)
 		}
 	},
@@ -313,10 +694,50 @@ func init() {
 
 	startCmd.Flags().StringVar(&analysisStartOffset, "analysisStartOffset", "", "Offset before analysis starts (typically the ramp-up window) in ISO8601 format (e.g., PT5M). Overrides YAML.")
 	startCmd.Flags().StringVar(&constantLoadTime, "constantLoadTime", "", "Constant load time in ISO8601 format (e.g., PT15M). Overrides YAML.")
-	startCmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags to add to the test session (merged with YAML tags)")
-	startCmd.Flags().StringVar(&annotation, "annotation", "", "Annotation message for the test session")
+	startCmd.Flags().StringVar(&tags, "tags", "", "Tags separated by --tags-separator (default ',') to add to the test session (merged with YAML tags)")
+	startCmd.Flags().StringVar(&tagsSeparator, "tags-separator", ",", "Delimiter used to split --tags")
+	startCmd.Flags().StringVar(&annotation, "annotation", "", "Annotation message for the test session (use - to read it from stdin)")
+	startCmd.Flags().IntVar(&maxAnnotationLength, "max-annotation-length", 4096, "Truncate the annotation to this many bytes (0 disables)")
+	startCmd.Flags().StringVar(&annotationsTemplateFile, "annotations-template-file", "", "text/template file re-read and rendered on each keep-alive; the result is sent as the keep-alive annotations")
+	startCmd.Flags().IntVar(&annotationMaxLines, "annotation-max-lines", 0, "Keep only the first N lines of the annotation, applied before --max-annotation-length (0 disables)")
 	startCmd.Flags().StringVar(&testVersion, "version", "", "Version of the test session. Overrides YAML.")
 	startCmd.Flags().StringVar(&buildResultsUrl, "buildResultsUrl", "", "URL to CI build results")
 	startCmd.Flags().StringSliceVar(&variablesFlag, "variable", []string{}, "Set variables (name=value)")
-	startCmd.Flags().StringSliceVar(&deepLinksFlag, "deeplink", []string{}, "Add deep links (title|url)")
+	startCmd.Flags().StringSliceVar(&deepLinksFlag, "deeplink", []string{}, "Add deep links as title|url[|type[|pluginName]]; type defaults to link")
+	startCmd.Flags().BoolVar(&reportDeepLinkOnComplete, "report-deeplink-on-complete", false, "Add a deep link to the Perfana report (requires appUrl) to the completion event")
+	startCmd.Flags().StringVar(&exportOnComplete, "export-on-complete", "", "Write a JSON export of the test run (status, check results, adapt conclusion) to this file after completion")
+	startCmd.Flags().StringVar(&writeJUnitXML, "write-junit-xml", "", "Write a JUnit XML report with one test case per SLO check to this file after completion")
+	startCmd.Flags().StringVar(&uploadTestPlan, "upload-test-plan", "", "Attach this test plan file (e.g. the k6 or JMeter script) to the test run after initialization")
+	startCmd.Flags().StringVar(&testPlanName, "test-plan-name", "", "Attachment name for --upload-test-plan (default: the file's basename)")
+	startCmd.Flags().StringVar(&saveTestRunURL, "save-testrun-url", "", "Write the Perfana dashboard URL of the test run to this file after initialization")
+	startCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the testRunId to this file after initialization, for later CI steps such as 'run stop --testRunId-file' (default $PERFANA_OUTPUT_FILE)")
+	startCmd.Flags().StringVar(&outputFileFormat, "output-file-format", "text", "Format of --output-file: text (the testRunId only) or json (test run metadata)")
+	startCmd.Flags().StringVar(&assertTestRunIDFormat, "assert-test-run-id-format", "", "Regular expression the testRunId returned by Perfana must match; the run is aborted otherwise")
+	startCmd.Flags().BoolVar(&progressBar, "progress-bar", false, "Render a progress bar for the test duration (default true in interactive terminals)")
+	startCmd.Flags().BoolVar(&printKeepAliveCount, "print-keep-alive-count-on-exit", false, "Print the number of successful and failed keep-alives when the run ends")
+	startCmd.Flags().BoolVar(&summaryOnComplete, "summary-on-complete", false, "Print a run summary after the final event (default true in interactive terminals)")
+	startCmd.Flags().StringVar(&startOutput, "output", "text", "Output format for the run summary: text or json (includes the SLO check results as assertions)")
+	startCmd.Flags().BoolVar(&failOnAssertions, "fail-on-assertions", true, "Exit 1 when the SLO checks or the adapt analysis of the completed test run fail")
+	startCmd.Flags().IntVar(&compressVariables, "compress-variables", 0, "Gzip and base64-encode variable values larger than this many bytes, appending _b64gz to the placeholder (0 disables)")
+	startCmd.Flags().BoolVar(&useServerTime, "use-server-time", false, "Compare the local clock with the Perfana server time and add the skew as the clockSkewMs variable")
+	startCmd.Flags().StringVar(&keepAliveIntervalFlag, "keepAliveInterval", "", "Interval between keep-alive events, in seconds or ISO 8601 (default: keepAliveInterval from the config, else 30s)")
+	startCmd.Flags().IntVar(&eventSchemaVersion, "event-schema-version", 0, "Schema version sent as eventSchemaVersion with test events (default: eventSchemaVersion from the config, else the current version)")
+	startCmd.Flags().BoolVar(&structuredStdout, "structured-stdout", false, "Write lifecycle events (started, keepalive, completed, aborted) as JSON lines to stdout")
+	startCmd.Flags().StringVar(&environmentFile, "environment-file", "", "Read KEY=VALUE lines from this file and set them as environment variables before loading the configuration")
+	startCmd.Flags().Float64Var(&injectFailure, "inject-failure", 0, "Probability (0.0-1.0) that a keep-alive send fails with a synthetic error; requires --allow-fault-injection")
+	startCmd.Flags().BoolVar(&allowFaultInjection, "allow-fault-injection", false, "Allow fault injection flags such as --inject-failure")
+	startCmd.Flags().StringVar(&heartbeatURL, "heartbeat-url", "", "URL to send a GET to after each successful keep-alive (e.g. a healthchecks.io ping URL)")
+	startCmd.Flags().StringVar(&heartbeatFailureURL, "heartbeat-url-on-failure", "", "URL to send a GET to after each failed keep-alive")
+	startCmd.Flags().BoolVar(&noCompleteOnTimeout, "no-complete-on-timeout", false, "When the test duration is reached, exit 0 without sending the completion event (e.g. when 'run stop' completes the run)")
+	startCmd.Flags().StringVar(&eventOnRampUpComplete, "event-on-rampup-complete", "", "Title of an event posted to Perfana when the ramp-up window (analysisStartOffset) ends")
+	startCmd.Flags().StringVar(&traceID, "trace-id", "", "External distributed trace ID; added as the traceId variable and sent as X-Trace-ID header")
+	startCmd.Flags().StringVar(&traceIDEnv, "trace-id-env", "", "Environment variable to read the trace ID from when --trace-id is not set")
+	startCmd.Flags().StringVar(&slackWebhookURL, "slack-notify-on-complete", "", "Slack incoming webhook URL notified with the run outcome on completion or abort")
+	startCmd.Flags().StringVar(&slackMessageTemplate, "slack-message-template", "", "Slack message text; supports {testRunId}, {status}, {duration} and {reportUrl}")
+	startCmd.Flags().StringVar(&abortFile, "abort-file", "", "Abort the run, as on SIGTERM, when this file appears; the file is removed on exit")
+	startCmd.Flags().StringVar(&abortFilePollInterval, "abort-file-poll-interval", "PT5S", "Interval between checks for --abort-file in ISO 8601 format")
+	startCmd.Flags().BoolVar(&noSignalHandler, "no-signal-handler", false, "Do not handle SIGINT/SIGTERM; the embedding program is responsible for aborting the run")
+	startCmd.Flags().StringVar(&rateLimitEvents, "rate-limit-events", "", "Maximum rate of events posted to Perfana as N/PERIOD (e.g. 10/s, 60/m); excess events are queued")
+	startCmd.Flags().IntVar(&eventQueueSize, "event-queue-size", 100, "Maximum number of queued events for --rate-limit-events; the oldest event is discarded when full")
+	startCmd.Flags().StringVar(&cloudProvider, "cloud-provider", "", "Add cloud instance metadata as variables: AWS, GCP or AZURE")
 }
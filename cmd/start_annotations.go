@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"perfana-cli/logger"
+	"strings"
+	"unicode/utf8"
+)
+
+const annotationTruncatedSuffix = "… [truncated]"
+
+// truncateAnnotation shortens annotation to at most maxLength bytes, including the
+// truncation suffix, without splitting a UTF-8 character. A maxLength <= 0 disables truncation.
+func truncateAnnotation(annotation string, maxLength int) string {
+	if maxLength <= 0 || len(annotation) <= maxLength {
+		return annotation
+	}
+
+	cut := maxLength - len(annotationTruncatedSuffix)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(annotation[cut]) {
+		cut--
+	}
+	truncated := annotation[:cut] + annotationTruncatedSuffix
+
+	logger.Warn("annotation truncated", "originalLength", len(annotation), "truncatedLength", len(truncated))
+	return truncated
+}
+
+// limitAnnotationLines keeps the first maxLines lines of annotation and replaces the
+// rest with a "… (+M more lines)" line. A maxLines <= 0 disables the limit.
+func limitAnnotationLines(annotation string, maxLines int) string {
+	lines := strings.Split(strings.TrimRight(annotation, "\n"), "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return annotation
+	}
+
+	more := len(lines) - maxLines
+	logger.Warn("annotation lines truncated", "originalLines", len(lines), "maxLines", maxLines)
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n… (+%d more lines)", more)
+}
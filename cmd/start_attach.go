@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// attach runs the test session in a background process and exits once it has started.
+var (
+	attach        bool
+	attachLogFile string
+)
+
+// attachedEnv marks the background process started by --attach.
+const attachedEnv = "PERFANA_CLI_ATTACHED"
+
+// attachedAnnotationEnv passes the --annotation read from stdin to the background
+// process, which has no stdin of its own.
+const attachedAnnotationEnv = "PERFANA_CLI_ATTACHED_ANNOTATION"
+
+// attachPollInterval is the interval between checks for the testRunId of the background process.
+const attachPollInterval = 200 * time.Millisecond
+
+// isAttachedProcess reports whether this process is the background process of --attach.
+func isAttachedProcess() bool {
+	return os.Getenv(attachedEnv) != ""
+}
+
+// readStdinAnnotation returns the --annotation read from stdin, or the annotation the
+// parent process read from its stdin when this is the background process of --attach.
+func readStdinAnnotation() (string, error) {
+	if isAttachedProcess() {
+		return os.Getenv(attachedAnnotationEnv), nil
+	}
+	stdinData, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(stdinData)), nil
+}
+
+// runAttached starts the test session in the background and exits 0 once its testRunId
+// has been written to --output-file, or 1 when the session could not be started.
+func runAttached() {
+	if dryRun {
+		fmt.Println("--attach cannot be combined with --dry-run")
+		os.Exit(1)
+	}
+	testRunIDFile := outputFile
+	if testRunIDFile == "" {
+		testRunIDFile = os.Getenv("PERFANA_OUTPUT_FILE")
+	}
+	if testRunIDFile == "" {
+		fmt.Println("--attach requires --output-file (or $PERFANA_OUTPUT_FILE) to pass the testRunId to 'run stop'")
+		os.Exit(1)
+	}
+
+	testRunID, pid, err := startAttached(testRunIDFile)
+	if err != nil {
+		fmt.Printf("Error starting test run in the background: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Test run %s started in background process %d\n", testRunID, pid)
+	fmt.Printf("Complete it with: perfana-cli run stop --testRunId-file %s\n", testRunIDFile)
+}
+
+// startAttached re-runs 'run start' with the same arguments in a detached process and
+// waits until that process has written the testRunId to testRunIDFile. The background
+// process sends the keep-alives until the test duration is reached or the test run is
+// completed by 'run stop'; it never sends the completion event itself.
+func startAttached(testRunIDFile string) (string, int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to locate perfana-cli executable: %w", err)
+	}
+
+	// A file left by an earlier run would be mistaken for the testRunId of this run
+	if err := os.Remove(testRunIDFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", 0, fmt.Errorf("failed to remove %s: %w", testRunIDFile, err)
+	}
+
+	child := exec.Command(executable, os.Args[1:]...)
+	child.Env = append(os.Environ(), attachedEnv+"=1")
+	if annotation == "-" {
+		stdinAnnotation, err := readStdinAnnotation()
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read annotation from stdin: %w", err)
+		}
+		child.Env = append(child.Env, attachedAnnotationEnv+"="+stdinAnnotation)
+	}
+	child.SysProcAttr = detachedProcAttr()
+	if attachLogFile != "" {
+		logFile, err := os.OpenFile(attachLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to open attach log file: %w", err)
+		}
+		defer logFile.Close()
+		child.Stdout = logFile
+		child.Stderr = logFile
+	}
+	if err := child.Start(); err != nil {
+		return "", 0, fmt.Errorf("failed to start background process: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- child.Wait()
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(attachPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-exited:
+			// The process may have written the file just before it exited
+			if testRunID, readErr := readTestRunIDFile(testRunIDFile); readErr == nil {
+				return testRunID, child.Process.Pid, nil
+			}
+			if err == nil {
+				err = errors.New("exit status 0")
+			}
+			return "", 0, fmt.Errorf("background process exited before the test run started (%v); see --attach-log-file for its output", err)
+
+		case <-sigChan:
+			_ = child.Process.Kill()
+			return "", 0, errors.New("interrupted before the test run started")
+
+		case <-ticker.C:
+			if testRunID, err := readTestRunIDFile(testRunIDFile); err == nil {
+				return testRunID, child.Process.Pid, nil
+			}
+		}
+	}
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&attach, "attach", false, "Start the test session in a background process that sends the keep-alives, and exit once the testRunId is written to --output-file; 'run stop' completes the run")
+	startCmd.Flags().StringVar(&attachLogFile, "attach-log-file", "", "Append the output of the --attach background process to this file (default: discarded)")
+}
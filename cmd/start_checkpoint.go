@@ -0,0 +1,13 @@
+package cmd
+
+// checkpointInterval and checkpointNameTemplate add timeline checkpoints: an event
+// posted to Perfana every interval, titled by the rendered template.
+var (
+	checkpointInterval     string
+	checkpointNameTemplate string
+)
+
+func init() {
+	startCmd.Flags().StringVar(&checkpointInterval, "checkpoint-interval", "", "Post a checkpoint event tagged 'checkpoint' to Perfana at this interval in ISO 8601 format (e.g. PT10M)")
+	startCmd.Flags().StringVar(&checkpointNameTemplate, "checkpoint-name-template", "Checkpoint {{.Checkpoint}} ({{.Elapsed}})", "text/template for the checkpoint event title; fields .Checkpoint, .Elapsed and .TestRunID")
+}
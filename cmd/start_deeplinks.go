@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"perfana-cli/perfana_client"
+	"strings"
+	"time"
+)
+
+// Flags for deep links generated for common observability tools
+var (
+	deeplinkGrafana  string
+	grafanaDashboard string
+	grafanaOrgID     int
+
+	deeplinkKibana     string
+	kibanaIndexPattern string
+	kibanaTimeField    string
+
+	deeplinkDatadog string
+	datadogQuery    string
+	datadogView     string
+
+	deeplinkJaeger  string
+	jaegerService   string
+	jaegerOperation string
+
+	deeplinkAppInsights  string
+	appInsightsWorkspace string
+
+	deeplinkSplunk string
+	splunkSearch   string
+	splunkApp      string
+
+	deeplinkNewRelic  string
+	newRelicAccountID string
+	newRelicAppID     string
+
+	deeplinkTempo     string
+	tempoService      string
+	tempoDatasourceID string
+	tempoTraceID      string
+
+	deeplinkElastic string
+	elasticIndex    string
+	elasticQuery    string
+)
+
+// parseDeepLinkFlag parses a --deeplink value in the format title|url[|type[|pluginName]].
+// The type defaults to "link".
+func parseDeepLinkFlag(value string) (perfana_client.DeepLink, error) {
+	parts := strings.Split(value, "|")
+	if len(parts) < 2 || len(parts) > 4 {
+		return perfana_client.DeepLink{}, fmt.Errorf("invalid --deeplink %q, expected title|url[|type[|pluginName]]", value)
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return perfana_client.DeepLink{}, fmt.Errorf("invalid --deeplink %q, title and url are required", value)
+	}
+
+	link := perfana_client.DeepLink{Name: parts[0], URL: parts[1], Type: "link"}
+	if len(parts) > 2 && parts[2] != "" {
+		link.Type = parts[2]
+	}
+	if len(parts) > 3 {
+		link.PluginName = parts[3]
+	}
+	return link, nil
+}
+
+// buildToolDeepLinks creates the deep links requested via the --deeplink-<tool> flags.
+// Time ranges start at runStart and stay open-ended, except for tools that need an
+// explicit end; those end at the expected runEnd.
+func buildToolDeepLinks(runStart, runEnd time.Time) ([]perfana_client.DeepLink, error) {
+	var links []perfana_client.DeepLink
+
+	if deeplinkGrafana != "" {
+		if grafanaDashboard == "" {
+			return nil, fmt.Errorf("--deeplink-grafana requires --grafana-dashboard")
+		}
+		links = append(links, grafanaDeepLink(deeplinkGrafana, grafanaDashboard, grafanaOrgID, runStart))
+	}
+
+	if deeplinkKibana != "" {
+		if kibanaIndexPattern == "" {
+			return nil, fmt.Errorf("--deeplink-kibana requires --kibana-index-pattern")
+		}
+		links = append(links, kibanaDeepLink(deeplinkKibana, kibanaIndexPattern, kibanaTimeField, runStart))
+	}
+
+	if deeplinkDatadog != "" {
+		if datadogQuery == "" {
+			return nil, fmt.Errorf("--deeplink-datadog requires --datadog-query")
+		}
+		link, err := datadogDeepLink(deeplinkDatadog, datadogQuery, datadogView, runStart)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if deeplinkJaeger != "" {
+		if jaegerService == "" {
+			return nil, fmt.Errorf("--deeplink-jaeger requires --jaeger-service")
+		}
+		links = append(links, jaegerDeepLink(deeplinkJaeger, jaegerService, jaegerOperation, runStart))
+	}
+
+	if deeplinkAppInsights != "" {
+		links = append(links, appInsightsDeepLink(deeplinkAppInsights, appInsightsWorkspace, runStart))
+	}
+
+	if deeplinkSplunk != "" {
+		if splunkSearch == "" {
+			return nil, fmt.Errorf("--deeplink-splunk requires --splunk-search")
+		}
+		links = append(links, splunkDeepLink(deeplinkSplunk, splunkSearch, splunkApp, runStart))
+	}
+
+	if deeplinkNewRelic != "" {
+		if newRelicAccountID == "" {
+			return nil, fmt.Errorf("--deeplink-new-relic requires --new-relic-account-id")
+		}
+		links = append(links, newRelicDeepLink(deeplinkNewRelic, newRelicAccountID, newRelicAppID, runStart, runEnd))
+	}
+
+	if deeplinkTempo != "" {
+		if tempoService == "" && tempoTraceID == "" {
+			return nil, fmt.Errorf("--deeplink-tempo requires --tempo-service or --tempo-trace-id")
+		}
+		link, err := tempoDeepLink(deeplinkTempo, tempoDatasourceID, tempoService, tempoTraceID, runStart, runEnd)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if deeplinkElastic != "" {
+		if elasticIndex == "" {
+			return nil, fmt.Errorf("--deeplink-elastic requires --elastic-index")
+		}
+		links = append(links, elasticDeepLink(deeplinkElastic, elasticIndex, elasticQuery, runStart))
+	}
+
+	return links, nil
+}
+
+// grafanaDeepLink links to a Grafana dashboard from the run start until now.
+func grafanaDeepLink(baseURL, dashboardUID string, orgID int, runStart time.Time) perfana_client.DeepLink {
+	query := url.Values{}
+	if orgID > 0 {
+		query.Set("orgId", fmt.Sprint(orgID))
+	}
+	query.Set("from", fmt.Sprint(runStart.UnixMilli()))
+	query.Set("to", "now")
+
+	return perfana_client.DeepLink{
+		Name:       "Grafana Dashboard",
+		URL:        fmt.Sprintf("%s/d/%s?%s", strings.TrimSuffix(baseURL, "/"), url.PathEscape(dashboardUID), query.Encode()),
+		Type:       "grafana",
+		PluginName: "grafana",
+	}
+}
+
+// kibanaDeepLink links to Kibana Discover for the index pattern, sorted on the
+// time field, from the run start until now.
+func kibanaDeepLink(baseURL, indexPattern, timeField string, runStart time.Time) perfana_client.DeepLink {
+	global := fmt.Sprintf("(time:(from:'%s',to:now))", runStart.UTC().Format(time.RFC3339))
+	app := fmt.Sprintf("(index:'%s',sort:!(!('%s',desc)))", risonEscape(indexPattern), risonEscape(timeField))
+
+	return perfana_client.DeepLink{
+		Name: "Kibana Logs",
+		URL: fmt.Sprintf("%s/app/discover#/?_g=%s&_a=%s",
+			strings.TrimSuffix(baseURL, "/"), url.QueryEscape(global), url.QueryEscape(app)),
+		Type:       "kibana",
+		PluginName: "kibana",
+	}
+}
+
+// datadogDeepLink links to the Datadog log explorer or metrics explorer for the
+// query, live from the run start.
+func datadogDeepLink(baseURL, query, view string, runStart time.Time) (perfana_client.DeepLink, error) {
+	params := url.Values{}
+	var name, path string
+	switch view {
+	case "logs":
+		name, path = "Datadog Logs", "/logs"
+		params.Set("query", query)
+	case "metrics":
+		name, path = "Datadog Metrics", "/metric/explorer"
+		params.Set("exp_metric", query)
+	default:
+		return perfana_client.DeepLink{}, fmt.Errorf("--datadog-view must be 'logs' or 'metrics' (got %q)", view)
+	}
+	params.Set("from_ts", fmt.Sprint(runStart.UnixMilli()))
+	params.Set("live", "true")
+
+	return perfana_client.DeepLink{
+		Name:       name,
+		URL:        fmt.Sprintf("%s%s?%s", strings.TrimSuffix(baseURL, "/"), path, params.Encode()),
+		Type:       "datadog",
+		PluginName: "datadog",
+	}, nil
+}
+
+// jaegerDeepLink links to a Jaeger trace search for the service (and optional
+// operation) from the run start. Jaeger expects timestamps in microseconds.
+func jaegerDeepLink(baseURL, service, operation string, runStart time.Time) perfana_client.DeepLink {
+	params := url.Values{}
+	params.Set("service", service)
+	if operation != "" {
+		params.Set("operation", operation)
+	}
+	params.Set("lookback", "custom")
+	params.Set("start", fmt.Sprint(runStart.UnixMicro()))
+
+	return perfana_client.DeepLink{
+		Name:       "Jaeger Traces",
+		URL:        fmt.Sprintf("%s/search?%s", strings.TrimSuffix(baseURL, "/"), params.Encode()),
+		Type:       "jaeger",
+		PluginName: "jaeger",
+	}
+}
+
+// appInsightsAnalyticsURL is the base URL of the Application Insights analytics portal.
+const appInsightsAnalyticsURL = "https://analytics.applicationinsights.io"
+
+// appInsightsDeepLink links to Application Insights analytics with a query on the
+// requests from the run start. With a workspace, the workspace-based AppRequests
+// table of that Log Analytics workspace is queried instead.
+func appInsightsDeepLink(appID, workspace string, runStart time.Time) perfana_client.DeepLink {
+	since := runStart.UTC().Format(time.RFC3339)
+	path := "/applications/" + url.PathEscape(appID)
+	query := fmt.Sprintf("requests | where timestamp >= datetime(%s)", since)
+	if workspace != "" {
+		path = "/workspaces/" + url.PathEscape(workspace)
+		query = fmt.Sprintf("AppRequests | where AppId == '%s' and TimeGenerated >= datetime(%s)", kqlEscape(appID), since)
+	}
+
+	return perfana_client.DeepLink{
+		Name:       "App Insights",
+		URL:        fmt.Sprintf("%s%s?q=%s", appInsightsAnalyticsURL, path, url.QueryEscape(query)),
+		Type:       "appinsights",
+		PluginName: "appinsights",
+	}
+}
+
+// splunkDeepLink links to a Splunk Web search for the query, from the run start
+// until now. Splunk Web takes the time range as the earliest and latest parameters.
+func splunkDeepLink(baseURL, search, app string, runStart time.Time) perfana_client.DeepLink {
+	// Splunk Web expects a full search string starting with a command
+	if trimmed := strings.TrimSpace(search); !strings.HasPrefix(trimmed, "search ") && !strings.HasPrefix(trimmed, "|") {
+		search = "search " + trimmed
+	}
+	params := url.Values{}
+	params.Set("q", search)
+	params.Set("earliest", fmt.Sprint(runStart.Unix()))
+	params.Set("latest", "now")
+
+	return perfana_client.DeepLink{
+		Name:       "Splunk Search",
+		URL:        fmt.Sprintf("%s/app/%s/search?%s", strings.TrimSuffix(baseURL, "/"), url.PathEscape(app), params.Encode()),
+		Type:       "splunk",
+		PluginName: "splunk",
+	}
+}
+
+// newRelicDeepLink links to the New Relic APM transactions of the application (or the
+// application list of the account when no app ID is given) for the test run window.
+func newRelicDeepLink(baseURL, accountID, appID string, runStart, runEnd time.Time) perfana_client.DeepLink {
+	path := fmt.Sprintf("/accounts/%s/applications", url.PathEscape(accountID))
+	if appID != "" {
+		path += fmt.Sprintf("/%s/transactions", url.PathEscape(appID))
+	}
+	params := url.Values{}
+	params.Set("from", fmt.Sprint(runStart.UnixMilli()))
+	params.Set("to", fmt.Sprint(runEnd.UnixMilli()))
+
+	return perfana_client.DeepLink{
+		Name:       "New Relic APM",
+		URL:        fmt.Sprintf("%s%s?%s", strings.TrimSuffix(baseURL, "/"), path, params.Encode()),
+		Type:       "newrelic",
+		PluginName: "newrelic",
+	}
+}
+
+// tempoDeepLink links to Grafana Explore with a Tempo query for the traces of the
+// service in the test run window, or for a single trace when a trace ID is given.
+func tempoDeepLink(grafanaURL, datasourceUID, service, traceID string, runStart, runEnd time.Time) (perfana_client.DeepLink, error) {
+	name, query := "Tempo Traces", fmt.Sprintf("{resource.service.name=%q}", service)
+	if traceID != "" {
+		name, query = "Tempo Trace", traceID
+	}
+	datasource := map[string]string{"type": "tempo", "uid": datasourceUID}
+	panes := map[string]interface{}{
+		"a": map[string]interface{}{
+			"datasource": datasourceUID,
+			"queries": []map[string]interface{}{{
+				"refId":      "A",
+				"datasource": datasource,
+				"queryType":  "traceql",
+				"query":      query,
+			}},
+			"range": map[string]string{
+				"from": fmt.Sprint(runStart.UnixMilli()),
+				"to":   fmt.Sprint(runEnd.UnixMilli()),
+			},
+		},
+	}
+	panesJSON, err := json.Marshal(panes)
+	if err != nil {
+		return perfana_client.DeepLink{}, fmt.Errorf("failed to marshal Grafana Explore state: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("schemaVersion", "1")
+	params.Set("panes", string(panesJSON))
+
+	return perfana_client.DeepLink{
+		Name:       name,
+		URL:        fmt.Sprintf("%s/explore?%s", strings.TrimSuffix(grafanaURL, "/"), params.Encode()),
+		Type:       "tempo",
+		PluginName: "tempo",
+	}, nil
+}
+
+// elasticDeepLink links to Kibana Discover for the index pattern with the Lucene
+// query pre-filled, from the run start until now.
+func elasticDeepLink(baseURL, index, query string, runStart time.Time) perfana_client.DeepLink {
+	global := fmt.Sprintf("(time:(from:'%s',to:now))", runStart.UTC().Format(time.RFC3339))
+	app := fmt.Sprintf("(index:'%s',query:(language:lucene,query:'%s'))", risonEscape(index), risonEscape(query))
+
+	return perfana_client.DeepLink{
+		Name: "Elasticsearch Logs",
+		URL: fmt.Sprintf("%s/app/discover#/?_g=%s&_a=%s",
+			strings.TrimSuffix(baseURL, "/"), url.QueryEscape(global), url.QueryEscape(app)),
+		Type:       "elasticsearch",
+		PluginName: "elasticsearch",
+	}
+}
+
+// risonEscape escapes a value for use inside a quoted Rison string, the notation
+// Kibana uses for its URL state: ! and ' are prefixed with !.
+func risonEscape(value string) string {
+	return strings.NewReplacer("!", "!!", "'", "!'").Replace(value)
+}
+
+// kqlEscape escapes a value for use inside a single-quoted KQL string literal, the
+// query language of Application Insights: \ and ' are prefixed with \.
+func kqlEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+func init() {
+	startCmd.Flags().StringVar(&deeplinkGrafana, "deeplink-grafana", "", "Grafana base URL; adds a deep link to --grafana-dashboard for the test run window")
+	startCmd.Flags().StringVar(&grafanaDashboard, "grafana-dashboard", "", "Grafana dashboard UID for --deeplink-grafana")
+	startCmd.Flags().IntVar(&grafanaOrgID, "grafana-org-id", 1, "Grafana organization ID for --deeplink-grafana")
+	startCmd.Flags().StringVar(&deeplinkKibana, "deeplink-kibana", "", "Kibana base URL; adds a Discover deep link for --kibana-index-pattern for the test run window")
+	startCmd.Flags().StringVar(&kibanaIndexPattern, "kibana-index-pattern", "", "Kibana index pattern for --deeplink-kibana")
+	startCmd.Flags().StringVar(&kibanaTimeField, "kibana-time-field", "@timestamp", "Kibana time field for --deeplink-kibana")
+	startCmd.Flags().StringVar(&deeplinkDatadog, "deeplink-datadog", "", "Datadog base URL (e.g. https://app.datadoghq.eu); adds a deep link for --datadog-query from the run start")
+	startCmd.Flags().StringVar(&datadogQuery, "datadog-query", "", "Datadog query for --deeplink-datadog")
+	startCmd.Flags().StringVar(&datadogView, "datadog-view", "logs", "Datadog view for --deeplink-datadog: logs or metrics")
+	startCmd.Flags().StringVar(&deeplinkJaeger, "deeplink-jaeger", "", "Jaeger base URL; adds a trace search deep link for --jaeger-service from the run start")
+	startCmd.Flags().StringVar(&jaegerService, "jaeger-service", "", "Service name for --deeplink-jaeger")
+	startCmd.Flags().StringVar(&jaegerOperation, "jaeger-operation", "", "Optional operation name for --deeplink-jaeger")
+	startCmd.Flags().StringVar(&deeplinkAppInsights, "deeplink-appinsights", "", "Application Insights app ID; adds an analytics deep link for the requests from the run start")
+	startCmd.Flags().StringVar(&appInsightsWorkspace, "appinsights-workspace", "", "Log Analytics workspace ID for a workspace-based --deeplink-appinsights resource")
+	startCmd.Flags().StringVar(&deeplinkSplunk, "deeplink-splunk", "", "Splunk Web base URL; adds a search deep link for --splunk-search from the run start")
+	startCmd.Flags().StringVar(&splunkSearch, "splunk-search", "", "Splunk search query for --deeplink-splunk")
+	startCmd.Flags().StringVar(&splunkApp, "splunk-app", "search", "Splunk app to run the search in for --deeplink-splunk")
+	startCmd.Flags().StringVar(&deeplinkNewRelic, "deeplink-new-relic", "", "New Relic base URL (e.g. https://rpm.newrelic.com); adds an APM deep link for the test run window")
+	startCmd.Flags().StringVar(&newRelicAccountID, "new-relic-account-id", "", "New Relic account ID for --deeplink-new-relic")
+	startCmd.Flags().StringVar(&newRelicAppID, "new-relic-app-id", "", "Optional New Relic application ID for --deeplink-new-relic")
+	startCmd.Flags().StringVar(&deeplinkTempo, "deeplink-tempo", "", "Grafana base URL; adds an Explore deep link querying Tempo for --tempo-service traces in the test run window")
+	startCmd.Flags().StringVar(&tempoService, "tempo-service", "", "Service name to query traces for with --deeplink-tempo")
+	startCmd.Flags().StringVar(&tempoDatasourceID, "tempo-datasource-id", "tempo", "UID of the Tempo data source in Grafana for --deeplink-tempo")
+	startCmd.Flags().StringVar(&tempoTraceID, "tempo-trace-id", "", "Optional trace ID; --deeplink-tempo then links to this trace instead of a service search")
+	startCmd.Flags().StringVar(&deeplinkElastic, "deeplink-elastic", "", "Kibana base URL of the Elasticsearch cluster; adds a Discover deep link for --elastic-index from the run start")
+	startCmd.Flags().StringVar(&elasticIndex, "elastic-index", "", "Index pattern for --deeplink-elastic")
+	startCmd.Flags().StringVar(&elasticQuery, "elastic-query", "", "Optional Lucene query pre-filled in Discover for --deeplink-elastic")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"perfana-cli/perfana_client"
+)
+
+func TestParseDeepLinkFlag(t *testing.T) {
+	tests := []struct {
+		input string
+		want  perfana_client.DeepLink
+	}{
+		{"Dashboard|https://grafana.example.com/d/abc",
+			perfana_client.DeepLink{Name: "Dashboard", URL: "https://grafana.example.com/d/abc", Type: "link"}},
+		{"Dashboard|https://grafana.example.com/d/abc|graph",
+			perfana_client.DeepLink{Name: "Dashboard", URL: "https://grafana.example.com/d/abc", Type: "graph"}},
+		{"Dashboard|https://grafana.example.com/d/abc|",
+			perfana_client.DeepLink{Name: "Dashboard", URL: "https://grafana.example.com/d/abc", Type: "link"}},
+		{"Dashboard|https://grafana.example.com/d/abc|graph|grafana",
+			perfana_client.DeepLink{Name: "Dashboard", URL: "https://grafana.example.com/d/abc", Type: "graph", PluginName: "grafana"}},
+		{" Dashboard | https://grafana.example.com/d/abc ",
+			perfana_client.DeepLink{Name: "Dashboard", URL: "https://grafana.example.com/d/abc", Type: "link"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDeepLinkFlag(tt.input)
+			if err != nil {
+				t.Fatalf("parseDeepLinkFlag(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseDeepLinkFlag(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDeepLinkFlagInvalid(t *testing.T) {
+	for _, input := range []string{
+		"Dashboard",
+		"Dashboard|https://grafana.example.com|graph|grafana|extra",
+		"|https://grafana.example.com",
+		"Dashboard|",
+		" | ",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := parseDeepLinkFlag(input); err == nil {
+				t.Errorf("parseDeepLinkFlag(%q) = %+v, want an error", input, got)
+			}
+		})
+	}
+}
+
+func TestAppInsightsDeepLinkEscapesAppID(t *testing.T) {
+	runStart := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	link := appInsightsDeepLink(`app'id\x`, "workspace", runStart)
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		t.Fatalf("invalid deep link URL %q: %v", link.URL, err)
+	}
+	want := `AppRequests | where AppId == 'app\'id\\x' and TimeGenerated >= datetime(2024-05-01T12:00:00Z)`
+	if got := u.Query().Get("q"); got != want {
+		t.Errorf("query = %q, want %q", got, want)
+	}
+}
+
+func TestKibanaDeepLinkEscapesRison(t *testing.T) {
+	runStart := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	link := kibanaDeepLink("https://kibana.example.com/", "logs-it's!", "@time'stamp", runStart)
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		t.Fatalf("invalid deep link URL %q: %v", link.URL, err)
+	}
+	state, err := url.ParseQuery(u.Fragment[strings.Index(u.Fragment, "?")+1:])
+	if err != nil {
+		t.Fatalf("invalid deep link fragment %q: %v", u.Fragment, err)
+	}
+	want := "(index:'logs-it!'s!!',sort:!(!('@time!'stamp',desc)))"
+	if got := state.Get("_a"); got != want {
+		t.Errorf("_a = %q, want %q", got, want)
+	}
+}
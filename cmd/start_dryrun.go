@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"perfana-cli/perfana_client"
+	"perfana-cli/scheduler"
+)
+
+// dryRun prints the start event instead of running the test.
+var dryRun bool
+
+// printDryRunMessage prints the PerfanaMessage that would start the test run as
+// indented JSON. The testRunId is empty, as it is assigned by Perfana on init.
+func printDryRunMessage(config perfana_client.Configuration, testCtx scheduler.TestContext) error {
+	message, err := perfana_client.BuildTestMessage(config, "", testCtx.TestEventOptions(), false)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(message, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal test event: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the test event that would start the run as JSON and exit without contacting Perfana")
+}
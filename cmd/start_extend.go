@@ -0,0 +1,13 @@
+package cmd
+
+// timeoutExtendSignal and timeoutExtendStep let operators extend a running test:
+// each time the signal is received the test duration grows by the step.
+var (
+	timeoutExtendSignal string
+	timeoutExtendStep   string
+)
+
+func init() {
+	startCmd.Flags().StringVar(&timeoutExtendSignal, "timeout-extend-signal", "", "Extend the test duration by --timeout-extend-step when this signal is received: SIGUSR1 or SIGUSR2 (not on Windows)")
+	startCmd.Flags().StringVar(&timeoutExtendStep, "timeout-extend-step", "PT5M", "Duration added per --timeout-extend-signal in ISO 8601 format")
+}
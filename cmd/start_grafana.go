@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"perfana-cli/logger"
+	"strings"
+	"time"
+)
+
+// Flags for verifying the Grafana annotation of the test run
+var (
+	waitForGrafanaAnnotation bool
+	grafanaURL               string
+	grafanaAPIKey            string
+	grafanaAnnotationTimeout string
+)
+
+// grafanaAnnotationPollInterval is the interval between Grafana annotation lookups.
+const grafanaAnnotationPollInterval = 5 * time.Second
+
+// waitForGrafanaTestRunAnnotation polls the Grafana annotations API for an annotation
+// tagged testRunId=<testRunID> until one is found or the timeout expires.
+func waitForGrafanaTestRunAnnotation(baseURL, apiKey, testRunID string, timeout time.Duration) error {
+	query := url.Values{}
+	query.Set("tags", "testRunId="+testRunID)
+	query.Set("limit", "1")
+	requestURL := fmt.Sprintf("%s/api/annotations?%s", strings.TrimSuffix(baseURL, "/"), query.Encode())
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		id, err := findGrafanaAnnotation(client, requestURL, apiKey)
+		if err != nil {
+			logger.Warn("grafana annotation lookup failed", "err", err)
+		} else if id != 0 {
+			logger.Info("grafana annotation found", "testRunId", testRunID, "annotationId", id)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no Grafana annotation for testRunId %s within %s", testRunID, timeout)
+		}
+		time.Sleep(grafanaAnnotationPollInterval)
+	}
+}
+
+// findGrafanaAnnotation returns the ID of the first annotation matching requestURL, or 0 if none.
+func findGrafanaAnnotation(client *http.Client, requestURL, apiKey string) (int64, error) {
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP error: %s: %s", resp.Status, string(body))
+	}
+
+	var annotations []struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(body, &annotations); err != nil {
+		return 0, fmt.Errorf("failed to parse Grafana annotations: %w", err)
+	}
+	if len(annotations) == 0 {
+		return 0, nil
+	}
+	return annotations[0].ID, nil
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&waitForGrafanaAnnotation, "wait-for-grafana-annotation", false, "After the test starts, wait until Grafana has an annotation tagged testRunId=<id>; abort the run otherwise")
+	startCmd.Flags().StringVar(&grafanaURL, "grafana-url", "", "Grafana base URL for --wait-for-grafana-annotation")
+	startCmd.Flags().StringVar(&grafanaAPIKey, "grafana-api-key", "", "Grafana API key for --wait-for-grafana-annotation")
+	startCmd.Flags().StringVar(&grafanaAnnotationTimeout, "grafana-annotation-timeout", "PT2M", "Maximum time to wait for the Grafana annotation in ISO8601 format")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"net/http"
+	"perfana-cli/perfana_client"
+	"time"
+)
+
+// skipTLSVerifyForHooks disables certificate verification for heartbeat and Slack calls only.
+var skipTLSVerifyForHooks bool
+
+// hookHTTPClientTimeout is the timeout for heartbeat and Slack calls.
+const hookHTTPClientTimeout = 5 * time.Second
+
+// hookHTTPClient returns the HTTP client used for heartbeat and Slack calls. With
+// --skip-tls-verify-for-hooks it is a separate client that accepts self-signed
+// certificates; the Perfana API client keeps verifying certificates.
+func hookHTTPClient(client *perfana_client.PerfanaClient) *http.Client {
+	if !skipTLSVerifyForHooks {
+		return client.HTTPClientWithTimeout(hookHTTPClientTimeout)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		Timeout: hookHTTPClientTimeout,
+	}
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&skipTLSVerifyForHooks, "skip-tls-verify-for-hooks", false, "Skip TLS certificate verification for heartbeat and Slack calls (not for the Perfana API)")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+)
+
+// k6EnvForward adds the K6_* environment variables as test run variables.
+var k6EnvForward bool
+
+// k6EnvVariables returns the K6_* environment variables keyed by their lowerCamelCase
+// name, e.g. K6_VUS=10 becomes k6Vus=10.
+func k6EnvVariables() map[string]string {
+	variables := make(map[string]string)
+	for _, env := range os.Environ() {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(key, "K6_") {
+			continue
+		}
+		variables[lowerCamelCase(key)] = value
+	}
+	return variables
+}
+
+// lowerCamelCase converts an UPPER_SNAKE_CASE name to lowerCamelCase.
+func lowerCamelCase(name string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(strings.ToLower(name), "_") {
+		if part == "" {
+			continue
+		}
+		if i > 0 && b.Len() > 0 {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&k6EnvForward, "k6-env-forward", false, "Add K6_* environment variables (e.g. K6_VUS, K6_DURATION) as variables, named in lowerCamelCase (k6Vus, k6Duration)")
+}
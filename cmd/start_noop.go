@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"perfana-cli/perfana_client"
+)
+
+// noop runs the start lifecycle against a local mock server instead of Perfana.
+var noop bool
+
+// startNoopServer starts a local server that answers the Perfana API calls made
+// during a test run with synthetic, valid responses. The test run reports as
+// completed once the final test event has been received.
+func startNoopServer(config perfana_client.Configuration) *httptest.Server {
+	testRunID := fmt.Sprintf("noop-%d", time.Now().Unix())
+	var completed atomic.Bool
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/init", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"testRunId": testRunID})
+	})
+	mux.HandleFunc("/api/test", func(w http.ResponseWriter, r *http.Request) {
+		var message perfana_client.PerfanaMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err == nil && message.Completed {
+			completed.Store(true)
+		}
+		writeJSON(w, map[string]string{})
+	})
+	mux.HandleFunc("/api/test-runs/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/check-results") {
+			writeJSON(w, []perfana_client.CheckResult{})
+			return
+		}
+		result := perfana_client.TestRunResult{
+			TestRunID:       testRunID,
+			TestEnvironment: config.Environment,
+			Workload:        config.Workload,
+			Completed:       completed.Load(),
+			Valid:           true,
+		}
+		result.SystemsUnderTest.Name = config.SystemUnderTest
+		writeJSON(w, result)
+	})
+	mux.HandleFunc("/api/adapt/conclusion/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, perfana_client.AdaptConclusion{TestRunID: testRunID, Conclusion: "NO_DIFFERENCE"})
+	})
+	mux.HandleFunc("/api/organizations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []interface{}{})
+	})
+	mux.HandleFunc("/api/time", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]time.Time{"time": time.Now()})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&noop, "noop", false, "Simulate the full run lifecycle against a local mock server instead of Perfana")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"perfana-cli/perfana_client"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Flags for the real-time SLA gate
+var (
+	abortOnSLABreach bool
+	slaFile          string
+	slaPollInterval  string
+)
+
+// loadSLAFile reads SLA rules from a YAML file with an slas list, the format
+// written by 'run fetch-config'.
+func loadSLAFile(path string) ([]perfana_client.SLADefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SLA file: %w", err)
+	}
+
+	var file struct {
+		SLAs []perfana_client.SLADefinition `yaml:"slas"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing SLA file: %w", err)
+	}
+	if len(file.SLAs) == 0 {
+		return nil, fmt.Errorf("no slas defined in %s", path)
+	}
+	return file.SLAs, nil
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&abortOnSLABreach, "abort-on-sla-breach", false, "Evaluate the SLAs from --sla-file during the run and abort (exit code 2) when one is violated")
+	startCmd.Flags().StringVar(&slaFile, "sla-file", "", "YAML file with an slas list (metric, operator, threshold) for --abort-on-sla-breach")
+	startCmd.Flags().StringVar(&slaPollInterval, "sla-poll-interval", "PT30S", "Interval between SLA checks in ISO8601 format")
+}
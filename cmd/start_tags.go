@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// Flags for tags derived from the run configuration
+var (
+	autoTagWorkloadHash  bool
+	tagVersionComponents bool
+)
+
+// workloadConfigHash returns the first 8 hex characters of the SHA-256 hash of the
+// workload, environment, version and variables. Variables are sorted by name so the
+// hash is the same for identical configurations.
+func workloadConfigHash(workload, environment, version string, variables map[string]string) string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(workload + "\n" + environment + "\n" + version + "\n")
+	for _, name := range names {
+		b.WriteString(name + "=" + variables[name] + "\n")
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// versionComponentTags splits a semantic version such as 2.14.3, v2.14.3-rc.1 or
+// 2.14.3+build.5 into major=, minor= and patch= tags, plus a prerelease= tag when
+// present. Build metadata is ignored. Missing or non-numeric components are skipped.
+func versionComponentTags(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	core, prerelease, _ := strings.Cut(version, "-")
+
+	var tags []string
+	for i, part := range strings.SplitN(core, ".", 3) {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			break
+		}
+		tags = append(tags, []string{"major", "minor", "patch"}[i]+"="+part)
+	}
+	if len(tags) > 0 && prerelease != "" {
+		tags = append(tags, "prerelease="+prerelease)
+	}
+	return tags
+}
+
+func init() {
+	startCmd.Flags().BoolVar(&autoTagWorkloadHash, "auto-tag-workload-hash", false, "Tag the run with config-hash=<hash> of the workload, environment, version and variables")
+	startCmd.Flags().BoolVar(&tagVersionComponents, "tag-version-components", false, "Tag the run with major=, minor= and patch= tags parsed from the semantic version")
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"perfana-cli/logger"
+	"time"
+)
+
+// Flags for waiting on the system under test before the session starts
+var (
+	pollForStartTrigger string
+	pollInterval        string
+	pollTimeout         string
+
+	awaitPorts   []string
+	awaitTimeout string
+
+	timeoutBeforeInit string
+)
+
+// preInitCountdownInterval is the interval between countdown log lines for --timeout-before-init.
+const preInitCountdownInterval = 10 * time.Second
+
+// awaitPortRetryInterval is the interval between TCP connection attempts for --await-port.
+const awaitPortRetryInterval = time.Second
+
+// waitForStartTrigger polls url until it returns HTTP 200, the timeout expires or ctx is cancelled.
+func waitForStartTrigger(ctx context.Context, url string, interval, timeout time.Duration) error {
+	client := &http.Client{Timeout: interval}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("invalid start trigger URL %s: %w", url, err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				logger.Info("start trigger received", "url", url)
+				return nil
+			}
+			logger.Info("start trigger not ready, retrying", "url", url, "status", resp.StatusCode)
+		} else {
+			logger.Info("start trigger not reachable, retrying", "url", url, "err", err)
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("start trigger %s did not return 200 within %s", url, timeout)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return fmt.Errorf("wait for start trigger cancelled: %w", err)
+		}
+	}
+}
+
+// waitForPorts dials each TCP address until all of them accept connections, the timeout
+// expires or ctx is cancelled.
+func waitForPorts(ctx context.Context, addrs []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	dialer := &net.Dialer{Timeout: awaitPortRetryInterval}
+
+	for _, addr := range addrs {
+		for {
+			conn, err := dialer.DialContext(ctx, "tcp", addr)
+			if err == nil {
+				conn.Close()
+				logger.Info("port reachable", "addr", addr)
+				break
+			}
+			logger.Info("port not reachable, retrying", "addr", addr, "err", err)
+
+			if time.Now().Add(awaitPortRetryInterval).After(deadline) {
+				return fmt.Errorf("port %s not reachable within %s", addr, timeout)
+			}
+			if err := sleepContext(ctx, awaitPortRetryInterval); err != nil {
+				return fmt.Errorf("wait for port %s cancelled: %w", addr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// waitBeforeInit sleeps for the given duration, logging a countdown. Cancelling ctx
+// cancels the wait with an error; the session has not been initialized at that point.
+func waitBeforeInit(ctx context.Context, wait time.Duration) error {
+	ticker := time.NewTicker(preInitCountdownInterval)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(wait)
+	done := time.After(wait)
+	logger.Info("waiting before init", "remaining", wait.String())
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("wait before init cancelled: %w", ctx.Err())
+		case <-ticker.C:
+			logger.Info("waiting before init", "remaining", time.Until(deadline).Round(time.Second).String())
+		}
+	}
+}
+
+// sleepContext sleeps for d, returning ctx.Err() early when ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func init() {
+	startCmd.Flags().StringVar(&pollForStartTrigger, "poll-for-start-trigger", "", "Poll this URL and start the session only once it returns HTTP 200")
+	startCmd.Flags().StringVar(&pollInterval, "poll-interval", "PT5S", "Interval between start trigger polls in ISO8601 format")
+	startCmd.Flags().StringVar(&pollTimeout, "poll-timeout", "PT5M", "Maximum time to wait for the start trigger in ISO8601 format")
+	startCmd.Flags().StringVar(&timeoutBeforeInit, "timeout-before-init", "", "Wait this long before initializing the session, e.g. for provisioning load generators, in ISO8601 format")
+	startCmd.Flags().StringArrayVar(&awaitPorts, "await-port", nil, "Wait until this HOST:PORT accepts TCP connections before the session starts (can be repeated)")
+	startCmd.Flags().StringVar(&awaitTimeout, "await-timeout", "PT2M", "Maximum time to wait for all --await-port ports in ISO8601 format")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of a Perfana run",
+	Long:  "The 'run status' command prints the current state of a test run as reported by the Perfana API.",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		output, _ := cmd.Flags().GetString("output")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		status, err := client.GetTestRunState(testRunID)
+		if err != nil {
+			fmt.Printf("Error getting test run status: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				fmt.Printf("Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "Test run ID:\t%s\n", status.TestRunID)
+			fmt.Fprintf(w, "State:\t%s\n", status.State)
+			fmt.Fprintf(w, "Start time:\t%s\n", status.StartTime)
+			fmt.Fprintf(w, "End time:\t%s\n", status.EndTime)
+			fmt.Fprintf(w, "Completion:\t%d%%\n", status.CompletionPercentage)
+			fmt.Fprintf(w, "Aborted:\t%t\n", status.Aborted)
+			w.Flush()
+		default:
+			fmt.Printf("Unknown output format %q (expected 'table' or 'json')\n", output)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().String("testRunId", "", "ID of the test run")
+	statusCmd.Flags().String("output", "table", "Output format: table or json")
+	_ = statusCmd.MarkFlagRequired("testRunId")
+}
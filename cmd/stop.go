@@ -16,22 +16,93 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
 )
 
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop a Perfana run",
-	Long:  "The 'run stop' command stops a currently running Perfana test.",
+	Long: `The 'run stop' command stops a currently running Perfana test by sending
+the completion event for the test run, optionally with an annotation recording the reason.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		testRunIDFile, _ := cmd.Flags().GetString("testRunId-file")
+		annotation, _ := cmd.Flags().GetString("annotation")
+
+		if testRunIDFile != "" {
+			var err error
+			testRunID, err = readTestRunIDFile(testRunIDFile)
+			if err != nil {
+				fmt.Printf("Error reading testRunId file: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := perfana_client.NewClient(clientConfig(fullConfig))
+		if err != nil {
+			fmt.Printf("Error initializing Perfana client: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := perfana_client.TestEventOptions{
+			Version:     fullConfig.Test.Version,
+			Annotations: annotation,
+			Tags:        fullConfig.Test.Tags,
+		}
+
 		fmt.Println("Stopping the Perfana run...")
-		// Add logic here to stop a running test (currently stubbed)
+		ctx, cancel := requestContext()
+		defer cancel()
+		if err := client.TestEvent(ctx, testRunID, opts, true); err != nil {
+			fmt.Printf("Error stopping test run: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Test run %s completed\n", testRunID)
 	},
 }
 
+// readTestRunIDFile reads the testRunId from a file written by 'run start --output-file',
+// in either the text or the json format.
+func readTestRunIDFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimSpace(string(data))
+	if strings.HasPrefix(content, "{") {
+		var output struct {
+			TestRunID string `json:"testRunId"`
+		}
+		if err := json.Unmarshal([]byte(content), &output); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		content = output.TestRunID
+	}
+	if content == "" {
+		return "", fmt.Errorf("no testRunId in %s", path)
+	}
+	return content, nil
+}
+
 func init() {
 	runCmd.AddCommand(stopCmd)
+
+	stopCmd.Flags().String("testRunId", "", "ID of the test run")
+	stopCmd.Flags().String("testRunId-file", "", "Read the testRunId from this file, as written by 'run start --output-file'")
+	stopCmd.Flags().String("annotation", "", "Annotation recording the reason for stopping")
+	stopCmd.MarkFlagsOneRequired("testRunId", "testRunId-file")
+	stopCmd.MarkFlagsMutuallyExclusive("testRunId", "testRunId-file")
 }
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+	"perfana-cli/util"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the metadata of a Perfana run",
+	Long: `The 'run update' command changes the version, CI build URL, annotation, tags, variables
+or deep links of a started test run. Only the given fields are changed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		version, _ := cmd.Flags().GetString("version")
+		buildResultsURL, _ := cmd.Flags().GetString("buildResultsUrl")
+		annotation, _ := cmd.Flags().GetString("annotation")
+		tagsFlag, _ := cmd.Flags().GetString("tags")
+		variableFlags, _ := cmd.Flags().GetStringSlice("variable")
+		deepLinkFlags, _ := cmd.Flags().GetStringSlice("deeplink")
+
+		patch := perfana_client.TestRunPatch{
+			Version:           version,
+			CIBuildResultsURL: buildResultsURL,
+			Annotations:       annotation,
+			Tags:              util.ParseTagsString(tagsFlag, ","),
+		}
+		for _, v := range variableFlags {
+			parts := strings.SplitN(v, "=", 2)
+			if len(parts) != 2 {
+				fmt.Printf("Invalid --variable %q, expected name=value\n", v)
+				os.Exit(1)
+			}
+			patch.Variables = append(patch.Variables, perfana_client.Variable{
+				Placeholder: strings.TrimSpace(parts[0]),
+				Value:       strings.TrimSpace(parts[1]),
+			})
+		}
+		for _, value := range deepLinkFlags {
+			link, err := parseDeepLinkFlag(value)
+			if err != nil {
+				fmt.Printf("Error parsing deep link: %v\n", err)
+				os.Exit(1)
+			}
+			patch.DeepLinks = append(patch.DeepLinks, link)
+		}
+		if reflect.ValueOf(patch).IsZero() {
+			fmt.Println("Nothing to update: set at least one of --version, --buildResultsUrl, --annotation, --tags, --variable or --deeplink")
+			os.Exit(1)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.UpdateTestRun(testRunID, patch); err != nil {
+			fmt.Printf("Error updating test run: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Test run %s updated\n", testRunID)
+	},
+}
+
+func init() {
+	runCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().String("testRunId", "", "ID of the test run")
+	updateCmd.Flags().String("version", "", "Version of the test run")
+	updateCmd.Flags().String("buildResultsUrl", "", "URL to CI build results")
+	updateCmd.Flags().String("annotation", "", "Annotation of the test run")
+	updateCmd.Flags().String("tags", "", "Comma-separated tags; replaces the tags of the test run")
+	updateCmd.Flags().StringSlice("variable", []string{}, "Set variables (name=value); replaces the variables of the test run")
+	updateCmd.Flags().StringSlice("deeplink", []string{}, "Deep links as title|url[|type[|pluginName]]; replaces the deep links of the test run")
+	_ = updateCmd.MarkFlagRequired("testRunId")
+}
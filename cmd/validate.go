@@ -16,17 +16,14 @@ var validateCmd = &cobra.Command{
 Checks all required fields, duration formats, event type schemas, and
 reports clear error messages.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		configPath := cfgFile
-		if configPath == "" {
+		configPath, explicit, err := util.ResolveConfigPath(cfgFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if !explicit {
 			if _, err := os.Stat("perfana.yaml"); err == nil {
 				configPath = "perfana.yaml"
-			} else {
-				homeDir, err := os.UserHomeDir()
-				if err != nil {
-					fmt.Println("Error finding home directory:", err)
-					os.Exit(1)
-				}
-				configPath = homeDir + "/.perfana-cli/perfana.yaml"
 			}
 		}
 
@@ -85,6 +82,12 @@ func runValidate(configPath string) error {
 		}
 	}
 
+	if config.Perfana.KeepAliveInterval != "" {
+		if _, err := util.ParseSecondsOrISODuration(config.Perfana.KeepAliveInterval); err != nil {
+			errors = append(errors, fmt.Sprintf("perfana.keepAliveInterval: invalid duration %q: %v", config.Perfana.KeepAliveInterval, err))
+		}
+	}
+
 	// Validate scheduler
 	if config.Scheduler.KeepAliveIntervalSeconds < 0 {
 		errors = append(errors, "scheduler.keepAliveIntervalSeconds must be >= 0")
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// variablesCmd groups the variable sub-commands
+var variablesCmd = &cobra.Command{
+	Use:   "variables",
+	Short: "Manage variables of a Perfana run",
+}
+
+var variablesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the variables of a test run",
+	Long:  "The 'run variables list' command prints the current variables of a test run.",
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		output, _ := cmd.Flags().GetString("output")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		variables, err := client.ListVariables(testRunID)
+		if err != nil {
+			fmt.Printf("Error listing variables: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch output {
+		case "json":
+			data, err := json.MarshalIndent(variables, "", "  ")
+			if err != nil {
+				fmt.Printf("Error generating JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		case "table":
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PLACEHOLDER\tVALUE")
+			for _, v := range variables {
+				fmt.Fprintf(w, "%s\t%s\n", v.Placeholder, v.Value)
+			}
+			w.Flush()
+		default:
+			fmt.Printf("Unknown output format %q (expected 'table' or 'json')\n", output)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(variablesCmd)
+	variablesCmd.AddCommand(variablesListCmd)
+
+	variablesListCmd.Flags().String("testRunId", "", "ID of the test run")
+	variablesListCmd.Flags().String("output", "table", "Output format: table or json")
+	_ = variablesListCmd.MarkFlagRequired("testRunId")
+}
@@ -17,6 +17,9 @@ package cmd
 
 import (
 	"fmt"
+	"perfana-cli/logger"
+	"perfana-cli/perfana_client"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -32,9 +35,42 @@ var versionCmd = &cobra.Command{
 	Short: "Print the version of perfana-cli",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("perfana-cli %s (commit: %s, built: %s)\n", version, commit, date)
+		fmt.Printf("Perfana server: %s\n", serverVersion())
 	},
 }
 
+// serverVersionTimeout limits the server version request, so version returns quickly
+// when the server cannot be reached.
+const serverVersionTimeout = 3 * time.Second
+
+// serverVersion returns the version of the configured Perfana server, or
+// "(server unreachable)" when it cannot be determined.
+func serverVersion() string {
+	v, err := fetchServerVersion()
+	if err != nil {
+		logger.Debug("failed to get server version", "err", err)
+		return "(server unreachable)"
+	}
+	return v
+}
+
+// fetchServerVersion requests the server version once, without retries or capability
+// discovery, and gives up after serverVersionTimeout.
+func fetchServerVersion() (string, error) {
+	fullConfig, err := loadFullConfig()
+	if err != nil {
+		return "", err
+	}
+	config := clientConfig(fullConfig)
+	config.MaxRetries = 0
+	config.DiscoverCapabilities = false
+	client, err := perfana_client.NewClient(config, perfana_client.WithRequestTimeout(serverVersionTimeout))
+	if err != nil {
+		return "", err
+	}
+	return client.GetServerVersion()
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 }
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+	"perfana-cli/util"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print the events of a Perfana run as they are recorded",
+	Long: `The 'run watch' command polls the events Perfana records for a test run and prints
+them until the run completes or is aborted. Press Ctrl-C to stop watching earlier.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		testRunID, _ := cmd.Flags().GetString("testRunId")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		intervalFlag, _ := cmd.Flags().GetString("interval")
+		output, _ := cmd.Flags().GetString("output")
+
+		if output != "text" && output != "json" {
+			fmt.Printf("Unknown output format %q (expected 'text' or 'json')\n", output)
+			os.Exit(1)
+		}
+
+		since, err := parseListTime(sinceFlag)
+		if err != nil {
+			fmt.Printf("Invalid --since: %v\n", err)
+			os.Exit(1)
+		}
+		interval, err := util.ParseISODurationToTimeDuration(intervalFlag)
+		if err != nil {
+			fmt.Printf("Invalid --interval: %v\n", err)
+			os.Exit(1)
+		}
+
+		fullConfig, err := loadFullConfig()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		config := clientConfig(fullConfig)
+		config.WatchInterval = interval
+
+		client, err := perfana_client.NewClient(config)
+		if err != nil {
+			fmt.Printf("Error initializing Perfana client: %v\n", err)
+			os.Exit(1)
+		}
+
+		err = client.Watch(testRunID, since, func(e perfana_client.Event) {
+			if output == "json" {
+				data, _ := json.Marshal(e)
+				fmt.Println(string(data))
+				return
+			}
+			line := fmt.Sprintf("%s  %s", e.Timestamp.Local().Format(time.RFC3339), e.Title)
+			if e.Description != "" {
+				line += "  " + e.Description
+			}
+			if len(e.Tags) > 0 {
+				line += "  [" + strings.Join(e.Tags, ", ") + "]"
+			}
+			fmt.Println(line)
+		})
+		if err != nil {
+			fmt.Printf("Error watching test run: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().String("testRunId", "", "Test run ID to watch")
+	watchCmd.Flags().String("since", "", "Only print events recorded after this time (RFC 3339 or YYYY-MM-DD; default: all events)")
+	watchCmd.Flags().String("interval", "PT5S", "Polling interval in ISO 8601 format")
+	watchCmd.Flags().String("output", "text", "Output format: text or json (one event per line)")
+	_ = watchCmd.MarkFlagRequired("testRunId")
+}
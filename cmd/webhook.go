@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"perfana-cli/perfana_client"
+)
+
+// webhookCmd groups the webhook sub-commands
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage Perfana server-side webhooks",
+	Long:  "The 'webhook' command registers webhooks that Perfana calls on test run events.",
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Register a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		url, _ := cmd.Flags().GetString("url")
+		events, _ := cmd.Flags().GetStringArray("event")
+		secret, _ := cmd.Flags().GetString("secret")
+		contentType, _ := cmd.Flags().GetString("content-type")
+		headerFlags, _ := cmd.Flags().GetStringArray("header")
+
+		headers := make(map[string]string)
+		for _, h := range headerFlags {
+			parts := strings.SplitN(h, "=", 2)
+			if len(parts) != 2 {
+				fmt.Printf("Invalid header %q (expected NAME=VALUE)\n", h)
+				os.Exit(1)
+			}
+			headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		webhookID, err := client.CreateWebhook(perfana_client.WebhookConfig{
+			URL:         url,
+			Events:      events,
+			Secret:      secret,
+			ContentType: contentType,
+			Headers:     headers,
+		})
+		if err != nil {
+			fmt.Printf("Error creating webhook: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(webhookID)
+	},
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered webhooks",
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		hooks, err := client.ListWebhooks()
+		if err != nil {
+			fmt.Printf("Error listing webhooks: %v\n", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tURL\tEVENTS\tCONTENT TYPE")
+		for _, h := range hooks {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", h.ID, h.URL, strings.Join(h.Events, ","), h.ContentType)
+		}
+		w.Flush()
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		webhookID, _ := cmd.Flags().GetString("webhook-id")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.DeleteWebhook(webhookID); err != nil {
+			fmt.Printf("Error deleting webhook: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Webhook %s deleted\n", webhookID)
+	},
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a test delivery to a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		webhookID, _ := cmd.Flags().GetString("webhook-id")
+
+		client, err := loadClient()
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.TestWebhook(webhookID); err != nil {
+			fmt.Printf("Error testing webhook: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Test delivery sent to webhook %s\n", webhookID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookCreateCmd, webhookListCmd, webhookDeleteCmd, webhookTestCmd)
+
+	webhookCreateCmd.Flags().String("url", "", "URL Perfana calls")
+	webhookCreateCmd.Flags().StringArray("event", nil, "Test run event that triggers the webhook (can be repeated)")
+	webhookCreateCmd.Flags().String("secret", "", "Secret used to sign the webhook deliveries")
+	webhookCreateCmd.Flags().String("content-type", "application/json", "Content type of the webhook deliveries")
+	webhookCreateCmd.Flags().StringArray("header", nil, "Extra header as NAME=VALUE (can be repeated)")
+	_ = webhookCreateCmd.MarkFlagRequired("url")
+
+	webhookDeleteCmd.Flags().String("webhook-id", "", "ID of the webhook")
+	_ = webhookDeleteCmd.MarkFlagRequired("webhook-id")
+
+	webhookTestCmd.Flags().String("webhook-id", "", "ID of the webhook")
+	_ = webhookTestCmd.MarkFlagRequired("webhook-id")
+}
@@ -1,6 +1,6 @@
 module perfana-cli
 
-go 1.20
+go 1.21
 
 require (
 	github.com/spf13/cobra v1.8.1
@@ -2,29 +2,67 @@ package logger
 
 import (
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"os"
 	"strings"
 )
 
-func Info(msg string, args ...any) {
-	log.Println("level=INFO " + msg + formatArgs(args))
+// defaultLogger receives the package-level log calls. It writes INFO and above to
+// stderr in text format until Configure or SetDefault replaces it.
+var defaultLogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+// New returns a logger writing to w at the given level (debug, info, warn or error)
+// in the given format (text or json).
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q (expected debug, info, warn or error)", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q (expected text or json)", format)
+	}
 }
 
-func Warn(msg string, args ...any) {
-	log.Println("level=WARN " + msg + formatArgs(args))
+// Configure replaces the default logger with one created by New.
+func Configure(w io.Writer, level, format string) error {
+	l, err := New(w, level, format)
+	if err != nil {
+		return err
+	}
+	SetDefault(l)
+	return nil
+}
+
+// Default returns the logger used by the package-level functions.
+func Default() *slog.Logger {
+	return defaultLogger
+}
+
+// SetDefault replaces the logger used by the package-level functions.
+func SetDefault(l *slog.Logger) {
+	defaultLogger = l
 }
 
 func Debug(msg string, args ...any) {
-	// Debug is a no-op by default; set log flags to enable if needed.
+	defaultLogger.Debug(msg, args...)
 }
 
-func formatArgs(args []any) string {
-	if len(args) == 0 {
-		return ""
-	}
-	var b strings.Builder
-	for i := 0; i+1 < len(args); i += 2 {
-		b.WriteString(fmt.Sprintf(" %v=%v", args[i], args[i+1]))
-	}
-	return b.String()
+func Info(msg string, args ...any) {
+	defaultLogger.Info(msg, args...)
+}
+
+func Warn(msg string, args ...any) {
+	defaultLogger.Warn(msg, args...)
+}
+
+func Error(msg string, args ...any) {
+	defaultLogger.Error(msg, args...)
 }
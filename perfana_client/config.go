@@ -1,17 +1,171 @@
 package perfana_client
 
-// Configuration struct to represent the YAML structure
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Configuration struct to represent the YAML structure. Fields with an env tag can be
+// overridden by that environment variable; see LoadConfigFromEnv.
 type Configuration struct {
-	ApiKey           string `yaml:"apiKey"`
-	ApiUrl           string `yaml:"apiUrl"`
-	AppUrl           string `yaml:"appUrl"`
-	ClientIdentifier string `yaml:"clientIdentifier"`
-	SystemUnderTest  string `yaml:"systemUnderTest"`
-	Environment      string `yaml:"environment"`
-	Workload         string `yaml:"workload"`
-	MTLS             struct {
-		Enabled    bool   `yaml:"enabled"`
-		ClientCert string `yaml:"clientCert"` // Path to the client certificate
-		ClientKey  string `yaml:"clientKey"`  // Path to the client private key
+	ApiKey           string `yaml:"apiKey" env:"PERFANA_API_KEY"`
+	ApiUrl           string `yaml:"apiUrl" env:"PERFANA_API_URL"`
+	AppUrl           string `yaml:"appUrl" env:"PERFANA_APP_URL"`
+	ClientIdentifier string `yaml:"clientIdentifier" env:"PERFANA_CLIENT_IDENTIFIER"`
+	SystemUnderTest  string `yaml:"systemUnderTest" env:"PERFANA_SYSTEM_UNDER_TEST"`
+	Environment      string `yaml:"environment" env:"PERFANA_ENVIRONMENT"`
+	Workload         string `yaml:"workload" env:"PERFANA_WORKLOAD"`
+	// DiscoverCapabilities queries /api/info when the client is created so
+	// feature-specific calls can check server support first.
+	DiscoverCapabilities bool `yaml:"discoverCapabilities" env:"PERFANA_DISCOVER_CAPABILITIES"`
+	// MaxRetries is the number of times a request is retried on network errors,
+	// 5xx and 429 responses. Requests that create a resource (Init, CreateRelease,
+	// NotifyDeployment, CreateWebhook, UploadAttachment) are only retried on a 429 or
+	// when they failed before being sent, so a retry cannot create a duplicate.
+	// RetryBackoff is the initial back-off (default 1s), doubled on each retry.
+	MaxRetries   int           `yaml:"maxRetries" env:"PERFANA_MAX_RETRIES"`
+	RetryBackoff time.Duration `yaml:"retryBackoff" env:"PERFANA_RETRY_BACKOFF"`
+	// KeepAliveInterval is the interval between keep-alive events during a test run,
+	// in plain seconds ("30") or ISO 8601 ("PT30S"). Defaults to 30 seconds.
+	KeepAliveInterval string `yaml:"keepAliveInterval,omitempty" env:"PERFANA_KEEP_ALIVE_INTERVAL"`
+	// EventSchemaVersion is the PerfanaMessage schema version sent with test events.
+	// Zero means CurrentEventSchemaVersion; init records the version it was generated with.
+	EventSchemaVersion int `yaml:"eventSchemaVersion,omitempty" env:"PERFANA_EVENT_SCHEMA_VERSION"`
+	// TraceID is an external distributed trace ID sent as X-Trace-ID header; set at runtime.
+	TraceID string `yaml:"-"`
+	// WatchInterval is the polling interval of Watch (default 5s); set at runtime.
+	WatchInterval time.Duration `yaml:"-"`
+	MTLS          struct {
+		Enabled    bool   `yaml:"enabled" env:"PERFANA_MTLS_ENABLED"`
+		ClientCert string `yaml:"clientCert,omitempty" env:"PERFANA_MTLS_CLIENT_CERT"` // PEM-encoded client certificate
+		ClientKey  string `yaml:"clientKey,omitempty" env:"PERFANA_MTLS_CLIENT_KEY"`   // PEM-encoded client private key
+		// ClientCertPath and ClientKeyPath are files with the PEM-encoded certificate and
+		// key, read when the client is created. They take precedence over ClientCert and ClientKey.
+		ClientCertPath string `yaml:"clientCertPath,omitempty" env:"PERFANA_MTLS_CLIENT_CERT_PATH"`
+		ClientKeyPath  string `yaml:"clientKeyPath,omitempty" env:"PERFANA_MTLS_CLIENT_KEY_PATH"`
+		// CACert and CACertPath hold PEM-encoded root CAs that are trusted for the Perfana
+		// server in addition to the system trust store. They apply also when mTLS is disabled.
+		CACert     string `yaml:"caCert,omitempty" env:"PERFANA_MTLS_CA_CERT"`
+		CACertPath string `yaml:"caCertPath,omitempty" env:"PERFANA_MTLS_CA_CERT_PATH"`
 	} `yaml:"mtls"`
 }
+
+// LoadClientKeyPair returns the mTLS client certificate, read from ClientCertPath and
+// ClientKeyPath when they are set and taken from the inline PEM fields otherwise.
+func (c Configuration) LoadClientKeyPair() (tls.Certificate, error) {
+	certPEM, err := mtlsPEM("clientCertPath", c.MTLS.ClientCertPath, c.MTLS.ClientCert)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := mtlsPEM("clientKeyPath", c.MTLS.ClientKeyPath, c.MTLS.ClientKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// HasCACert reports whether a custom root CA is configured.
+func (c Configuration) HasCACert() bool {
+	return c.MTLS.CACertPath != "" || c.MTLS.CACert != ""
+}
+
+// LoadRootCAs returns the system trust store extended with the configured CA
+// certificates, read from CACertPath when it is set and taken from CACert otherwise.
+func (c Configuration) LoadRootCAs() (*x509.CertPool, error) {
+	caPEM, err := mtlsPEM("caCertPath", c.MTLS.CACertPath, c.MTLS.CACert)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("mtls.caCert or mtls.caCertPath must contain a PEM certificate")
+	}
+	return pool, nil
+}
+
+// mtlsPEM returns the content of the file at path, or inline when path is empty.
+func mtlsPEM(field, path, inline string) ([]byte, error) {
+	if path == "" {
+		return []byte(inline), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mtls.%s: %w", field, err)
+	}
+	return data, nil
+}
+
+// urlPathUnsafeChars are characters that would break the API URL paths and queries
+// the system under test, environment and workload are inserted into.
+const urlPathUnsafeChars = "/?#%\\"
+
+// Validate checks the required fields and returns all problems at once, joined with
+// errors.Join, or nil when the configuration is valid.
+func (c Configuration) Validate() error {
+	var errs []error
+
+	if c.ApiUrl == "" {
+		errs = append(errs, errors.New("apiUrl is required"))
+	} else if u, err := neturl.Parse(c.ApiUrl); err != nil {
+		errs = append(errs, fmt.Errorf("apiUrl is not a valid URL: %w", err))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("apiUrl must be an http or https URL (got %q)", c.ApiUrl))
+	}
+
+	if c.ApiKey == "" {
+		errs = append(errs, errors.New("apiKey is required"))
+	}
+
+	names := []struct {
+		field string
+		value string
+	}{
+		{"systemUnderTest", c.SystemUnderTest},
+		{"environment", c.Environment},
+		{"workload", c.Workload},
+	}
+	for _, n := range names {
+		if n.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", n.field))
+		} else if strings.ContainsAny(n.value, urlPathUnsafeChars) || strings.IndexFunc(n.value, unicode.IsControl) >= 0 {
+			errs = append(errs, fmt.Errorf("%s %q must not contain control characters or any of %s", n.field, n.value, urlPathUnsafeChars))
+		}
+	}
+
+	if c.MTLS.Enabled {
+		pemFields := []struct {
+			field  string
+			path   string
+			inline string
+		}{
+			{"clientCert", c.MTLS.ClientCertPath, c.MTLS.ClientCert},
+			{"clientKey", c.MTLS.ClientKeyPath, c.MTLS.ClientKey},
+		}
+		for _, f := range pemFields {
+			data, err := mtlsPEM(f.field+"Path", f.path, f.inline)
+			if err != nil {
+				errs = append(errs, err)
+			} else if block, _ := pem.Decode(data); block == nil || len(block.Bytes) == 0 {
+				errs = append(errs, fmt.Errorf("mtls.%s or mtls.%sPath must contain a PEM block when mTLS is enabled", f.field, f.field))
+			}
+		}
+	}
+	if c.HasCACert() {
+		if _, err := c.LoadRootCAs(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
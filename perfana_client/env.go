@@ -0,0 +1,81 @@
+package perfana_client
+
+import (
+	"fmt"
+	"os"
+	"perfana-cli/logger"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// LoadConfigFromEnv returns a Configuration populated from the PERFANA_* environment
+// variables named in the env struct tags of Configuration.
+func LoadConfigFromEnv() Configuration {
+	var config Configuration
+	ApplyEnv(&config)
+	return config
+}
+
+// ApplyEnv overrides the fields of config for which the env tag names a set
+// environment variable, so environment variables win over file-based values.
+// Values that cannot be parsed are logged and skipped.
+func ApplyEnv(config *Configuration) {
+	applyEnv(reflect.ValueOf(config).Elem())
+}
+
+// applyEnv sets the tagged fields of the struct v, recursing into nested structs.
+func applyEnv(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Duration(0)) {
+			applyEnv(field)
+			continue
+		}
+
+		name := t.Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			logger.Warn("ignoring invalid environment variable", "name", name, "err", err)
+		}
+	}
+}
+
+// setField parses value into field according to the field's type.
+func setField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package perfana_client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The error types below are returned (wrapped) by PerfanaClient methods so callers
+// can handle failures programmatically with errors.As:
+//
+//	var httpErr *perfana_client.HTTPError
+//	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+//		// test run does not exist
+//	}
+//
+//	var netErr *perfana_client.NetworkError
+//	if errors.As(err, &netErr) {
+//		// server unreachable, worth retrying
+//	}
+
+// HTTPError is returned when the Perfana API responds with an error status.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay requested by the Retry-After header, if present.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// NotFoundError is returned when the requested resource does not exist (404),
+// for methods that address a single resource such as DeleteTestRun.
+type NotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Resource, e.ID)
+}
+
+// NetworkError is returned when the request could not be sent or no response was received.
+type NetworkError struct {
+	Wrapped error
+	// RequestSent is true when the request was written to the connection before the
+	// failure, so the server may have processed it.
+	RequestSent bool
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error: %v", e.Wrapped)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Wrapped
+}
+
+// AuthError is returned when the Perfana API rejects the API key (401 or 403).
+type AuthError struct {
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed (%d): check the configured apiKey", e.StatusCode)
+}
+
+// CircuitOpenError is reserved for requests refused locally by a circuit breaker after
+// repeated failures. The client has no circuit breaker yet, so it is not returned;
+// callers can already check for it with errors.As.
+type CircuitOpenError struct{}
+
+func (e *CircuitOpenError) Error() string {
+	return "circuit open: too many consecutive failures, request not sent"
+}
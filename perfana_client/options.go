@@ -0,0 +1,51 @@
+package perfana_client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ClientOption customizes a PerfanaClient created by NewClient.
+type ClientOption func(*PerfanaClient)
+
+// WithLogger makes the client write its diagnostics to l instead of the default logger.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *PerfanaClient) {
+		c.log = l
+	}
+}
+
+// WithHTTPClient makes the client send its requests with httpClient, e.g. one with a
+// custom http.RoundTripper. It replaces the client built from the mTLS settings.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *PerfanaClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseContext makes the requests of methods without a context parameter derive
+// their context from ctx, so cancelling ctx cancels those requests in flight.
+func WithBaseContext(ctx context.Context) ClientOption {
+	return func(c *PerfanaClient) {
+		c.baseCtx = ctx
+	}
+}
+
+// WithRequestTimeout limits each request of methods without a context parameter to
+// timeout instead of 30 seconds. A timeout of 0 disables the limit.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *PerfanaClient) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithoutSignalHandler stops an EventScheduler using this client from handling SIGINT
+// and SIGTERM, for programs that handle signals themselves. The program then stops
+// the run with EventScheduler.Abort.
+func WithoutSignalHandler() ClientOption {
+	return func(c *PerfanaClient) {
+		c.noSignalHandler = true
+	}
+}
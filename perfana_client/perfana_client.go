@@ -8,13 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"perfana-cli/util"
+	"net/http/httptrace"
+	neturl "net/url"
+	"perfana-cli/logger"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
 // PerfanaEvent represents the structure of the JSON payload for the /api/events endpoint
 type PerfanaEvent struct {
+	TestRunID       string   `json:"testRunId,omitempty"`
 	SystemUnderTest string   `json:"systemUnderTest"`
 	TestEnvironment string   `json:"testEnvironment"`
 	Workload        string   `json:"workload"`
@@ -23,6 +29,17 @@ type PerfanaEvent struct {
 	Tags            []string `json:"tags,omitempty"`
 }
 
+// EventResponse is the response of Perfana to a posted PerfanaEvent. EventID is
+// empty when the Perfana version does not return it.
+type EventResponse struct {
+	EventID string `json:"eventId"`
+	Message string `json:"message"`
+}
+
+// CurrentEventSchemaVersion is the version of the PerfanaMessage payload produced by
+// this client. Bump it with each breaking change to the payload.
+const CurrentEventSchemaVersion = 1
+
 // PerfanaMessage represents the JSON payload sent to start a session
 type PerfanaMessage struct {
 	TestRunID           string     `json:"testRunId"`
@@ -39,6 +56,7 @@ type PerfanaMessage struct {
 	Tags                []string   `json:"tags,omitempty"`        // Optional
 	Variables           []Variable `json:"variables,omitempty"`   // Optional
 	DeepLinks           []DeepLink `json:"deepLinks,omitempty"`   // Optional
+	EventSchemaVersion  int        `json:"eventSchemaVersion,omitempty"`
 }
 
 // Variable is used in PerfanaMessage to send key-value pairs
@@ -68,6 +86,7 @@ type TestRunResult struct {
 	AnalysisStartOffset  int      `json:"analysis_start_offset"`
 	Completed            bool     `json:"completed"`
 	Abort                bool     `json:"abort"`
+	Paused               bool     `json:"paused"`
 	Valid                bool     `json:"valid"`
 	CompletionPercentage int      `json:"completion_percentage"`
 	Status *struct {
@@ -140,69 +159,150 @@ type AdaptConclusion struct {
 	Differences    []AdaptMetric `json:"differences"`
 }
 
+// APIInfo describes the Perfana server version and the features it supports.
+type APIInfo struct {
+	Version           string   `json:"version"`
+	SupportedFeatures []string `json:"supportedFeatures"`
+}
+
+// CostEstimate holds the estimated cloud cost attributed to a test run.
+type CostEstimate struct {
+	Currency           string  `json:"currency"`
+	InfrastructureCost float64 `json:"infrastructureCost"`
+	StorageCost        float64 `json:"storageCost"`
+	TotalCost          float64 `json:"totalCost"`
+}
+
+// Annotation is a timeline marker on a test run.
+type Annotation struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Timestamp   time.Time `json:"timestamp"`
+	Tags        []string  `json:"tags"`
+}
+
+// SLADefinition is a service level requirement on a metric.
+type SLADefinition struct {
+	Metric    string  `json:"metric" yaml:"metric"`
+	Operator  string  `json:"operator" yaml:"operator"`
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+}
+
+// ServerConfig is test configuration stored centrally in Perfana.
+type ServerConfig struct {
+	Variables map[string]string `json:"variables" yaml:"variables,omitempty"`
+	DeepLinks []DeepLink        `json:"deepLinks" yaml:"deepLinks,omitempty"`
+	Tags      []string          `json:"tags" yaml:"tags,omitempty"`
+	SLAs      []SLADefinition   `json:"slas" yaml:"slas,omitempty"`
+}
+
 // PerfanaClient is the client implementation for Perfana
 type PerfanaClient struct {
-	httpClient *http.Client
-	config     Configuration
+	httpClient     *http.Client
+	config         Configuration
+	apiInfo        *APIInfo
+	log            *slog.Logger
+	baseCtx        context.Context
+	requestTimeout time.Duration
+	// noSignalHandler is set by WithoutSignalHandler.
+	noSignalHandler bool
 }
 
+// SignalHandlerDisabled reports whether the client was created with WithoutSignalHandler.
+func (c *PerfanaClient) SignalHandlerDisabled() bool {
+	return c.noSignalHandler
+}
+
+// defaultRequestTimeout limits each Perfana API request unless WithRequestTimeout is used.
+const defaultRequestTimeout = 30 * time.Second
+
 // NewClient initializes and returns a Perfana client
-func NewClient(config Configuration) (*PerfanaClient, error) {
-	if config.ApiUrl == "" {
-		return nil, errors.New("apiUrl is required")
+func NewClient(config Configuration, opts ...ClientOption) (*PerfanaClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	if !config.MTLS.Enabled {
-		// Default HTTP Client
-		httpClient := &http.Client{
-			Timeout: 30 * time.Second,
-		}
-		return &PerfanaClient{
+	var client *PerfanaClient
+	if !config.MTLS.Enabled && !config.HasCACert() {
+		// Default HTTP Client; requests are limited by requestTimeout
+		httpClient := &http.Client{}
+		client = &PerfanaClient{
 			httpClient: httpClient,
 			config:     config,
-		}, nil
+		}
 	} else {
 		tlsClient, err := createTLSClient(config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create TLS client: %w", err)
 		}
-		return &PerfanaClient{
+		client = &PerfanaClient{
 			httpClient: tlsClient,
 			config:     config,
-		}, nil
+		}
 	}
-}
 
-// createTLSClient sets up a HTTP client with mutual TLS
-func createTLSClient(config Configuration) (*http.Client, error) {
-	// Load client certificate and key from PEM strings
-	cert, err := tls.X509KeyPair([]byte(config.MTLS.ClientCert), []byte(config.MTLS.ClientKey))
-	if err != nil {
-		return nil, fmt.Errorf("failed to load client certificate and key: %w", err)
+	client.log = logger.Default()
+	client.baseCtx = context.Background()
+	client.requestTimeout = defaultRequestTimeout
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if config.DiscoverCapabilities {
+		info, err := client.GetAPIInfo()
+		if err != nil {
+			// Unknown capabilities: feature checks fall back to attempting the request.
+			client.log.Warn("failed to discover server capabilities", "err", err)
+		} else {
+			client.apiInfo = info
+		}
 	}
 
+	return client, nil
+}
+
+// createTLSClient sets up a HTTP client with mutual TLS and/or a custom root CA
+func createTLSClient(config Configuration) (*http.Client, error) {
 	// Configure TLS
 	tlsConfig := &tls.Config{
-		Certificates:       []tls.Certificate{cert},
 		InsecureSkipVerify: false, // Ensure certificate validation
 	}
 
+	if config.MTLS.Enabled {
+		// Load client certificate and key from the PEM files or strings
+		cert, err := config.LoadClientKeyPair()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate and key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.HasCACert() {
+		// Verify the server against the custom root CA
+		rootCAs, err := config.LoadRootCAs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA certificate: %w", err)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
 	// Create a transport with TLS configuration
 	transport := &http.Transport{
 		TLSClientConfig: tlsConfig,
 	}
 
-	// Return a client with the transport
+	// Return a client with the transport; requests are limited by requestTimeout
 	return &http.Client{
-		Timeout:   30 * time.Second,
 		Transport: transport,
 	}, nil
 }
 
 // Init performs a POST request to /api/init and starts a test run.
 // It sends systemUnderTest, environment, and workload in the JSON payload
-// and receives a testRunId in the response.
-func (c *PerfanaClient) Init() (string, error) {
+// and receives a testRunId in the response. The request is bound to ctx only;
+// the client's request timeout does not apply.
+func (c *PerfanaClient) Init(ctx context.Context) (string, error) {
 	url := fmt.Sprintf("%s/api/init", c.config.ApiUrl)
 
 	// Prepare the request body
@@ -216,7 +316,7 @@ func (c *PerfanaClient) Init() (string, error) {
 	}
 
 	// Make the HTTP request
-	resp, err := c.makeRequest("POST", url, bytes.NewReader(reqBody))
+	resp, err := c.makeCreateRequest(ctx, "POST", url, bytes.NewReader(reqBody), 0)
 	if err != nil {
 		return "", err
 	}
@@ -236,51 +336,54 @@ func (c *PerfanaClient) Init() (string, error) {
 	return response.TestRunID, nil
 }
 
-// TestEvent makes a POST request to start a Perfana session
-func (c *PerfanaClient) TestEvent(testRunID string, additionalData map[string]interface{}, completed bool) error {
-	url := fmt.Sprintf("%s/api/test", c.config.ApiUrl)
-
-	// Create the JSON payload (PerfanaMessage with additional fields as needed)
-	message := PerfanaMessage{
-		TestRunID:       testRunID,
-		Workload:        c.config.Workload,
-		TestEnvironment: c.config.Environment,
-		SystemUnderTest: c.config.SystemUnderTest,
-		Completed:       completed,
-	}
+// TestEventOptions holds the optional fields of the test events sent by TestEvent
+// and AbortTest. Durations are in seconds.
+type TestEventOptions struct {
+	Version             string
+	CIBuildResultsURL   string
+	AnalysisStartOffset int
+	Duration            int
+	Annotations         string
+	Tags                []string
+	Variables           []Variable
+	DeepLinks           []DeepLink
+}
 
-	// Add optional values from additionalData map (if provided)
-	if version, ok := additionalData["version"]; ok {
-		message.Version = version.(string)
+// BuildTestMessage returns the PerfanaMessage that TestEvent sends for config.
+func BuildTestMessage(config Configuration, testRunID string, opts TestEventOptions, completed bool) (PerfanaMessage, error) {
+	if opts.AnalysisStartOffset < 0 {
+		return PerfanaMessage{}, fmt.Errorf("invalid analysisStartOffset: must be non-negative")
 	}
-	if cibuildResultsUrl, ok := additionalData["cibuildResultsUrl"]; ok {
-		message.CIBuildResultsURL = cibuildResultsUrl.(string)
+	if opts.Duration < 0 {
+		return PerfanaMessage{}, fmt.Errorf("invalid duration: must be non-negative")
 	}
-	if analysisStartOffset, ok := additionalData["analysisStartOffset"]; ok {
-		seconds, err := normalizeDurationToSeconds(analysisStartOffset)
-		if err != nil {
-			return fmt.Errorf("invalid analysisStartOffset: %w", err)
-		}
-		message.AnalysisStartOffset = seconds
-	}
-	if duration, ok := additionalData["duration"]; ok {
-		seconds, err := normalizeDurationToSeconds(duration)
-		if err != nil {
-			return fmt.Errorf("invalid duration: %w", err)
-		}
-		message.Duration = seconds
-	}
-	if annotations, ok := additionalData["annotations"]; ok {
-		message.Annotations = annotations.(string)
-	}
-	if tags, ok := additionalData["tags"]; ok {
-		message.Tags = tags.([]string)
-	}
-	if variables, ok := additionalData["variables"]; ok {
-		message.Variables = variables.([]Variable)
-	}
-	if deepLinks, ok := additionalData["deepLinks"]; ok {
-		message.DeepLinks = deepLinks.([]DeepLink)
+
+	return PerfanaMessage{
+		TestRunID:           testRunID,
+		Workload:            config.Workload,
+		TestEnvironment:     config.Environment,
+		SystemUnderTest:     config.SystemUnderTest,
+		Version:             opts.Version,
+		CIBuildResultsURL:   opts.CIBuildResultsURL,
+		AnalysisStartOffset: opts.AnalysisStartOffset,
+		Duration:            opts.Duration,
+		Completed:           completed,
+		Annotations:         opts.Annotations,
+		Tags:                opts.Tags,
+		Variables:           opts.Variables,
+		DeepLinks:           opts.DeepLinks,
+		EventSchemaVersion:  eventSchemaVersion(config),
+	}, nil
+}
+
+// TestEvent makes a POST request to start a Perfana session. The request is bound
+// to ctx only; the client's request timeout does not apply.
+func (c *PerfanaClient) TestEvent(ctx context.Context, testRunID string, opts TestEventOptions, completed bool) error {
+	url := fmt.Sprintf("%s/api/test", c.config.ApiUrl)
+
+	message, err := BuildTestMessage(c.config, testRunID, opts, completed)
+	if err != nil {
+		return err
 	}
 
 	reqBody, err := json.Marshal(message)
@@ -288,95 +391,156 @@ func (c *PerfanaClient) TestEvent(testRunID string, additionalData map[string]in
 		return fmt.Errorf("failed to marshal JSON: %v", err)
 	}
 
-	_, err = c.makeRequest("POST", url, bytes.NewReader(reqBody))
+	_, err = c.makeRequestContext(ctx, "POST", url, bytes.NewReader(reqBody), 0)
 	return err
 }
 
-func normalizeDurationToSeconds(raw interface{}) (int, error) {
-	switch v := raw.(type) {
-	case int:
-		if v < 0 {
-			return 0, fmt.Errorf("must be non-negative")
-		}
-		return v, nil
-	case int64:
-		if v < 0 {
-			return 0, fmt.Errorf("must be non-negative")
-		}
-		return int(v), nil
-	case float64:
-		if v < 0 {
-			return 0, fmt.Errorf("must be non-negative")
+// eventSchemaVersion returns the event schema version configured in config,
+// defaulting to CurrentEventSchemaVersion.
+func eventSchemaVersion(config Configuration) int {
+	if config.EventSchemaVersion > 0 {
+		return config.EventSchemaVersion
+	}
+	return CurrentEventSchemaVersion
+}
+
+// Shared helper method for HTTP requests. Network errors, 5xx and 429 responses are
+// retried up to MaxRetries times with exponential back-off; see retry.go. Requests that
+// create a resource use makeCreateRequest instead.
+func (c *PerfanaClient) makeRequest(method, url string, body io.Reader) ([]byte, error) {
+	return c.makeRequestContext(c.baseCtx, method, url, body, c.requestTimeout)
+}
+
+// makeRequestContext is makeRequest bound to ctx, with each attempt limited to
+// timeout (0 disables the limit). Cancelling ctx also stops waiting between retries.
+func (c *PerfanaClient) makeRequestContext(ctx context.Context, method, url string, body io.Reader, timeout time.Duration) ([]byte, error) {
+	return c.retryRequest(ctx, method, url, body, timeout, isRetriable)
+}
+
+// makeCreateRequest is makeRequestContext for requests that create a resource, such as
+// POST /api/init. Once the server has received such a request, sending it again could
+// create the resource twice, so it is only retried when it was not sent or got a 429.
+func (c *PerfanaClient) makeCreateRequest(ctx context.Context, method, url string, body io.Reader, timeout time.Duration) ([]byte, error) {
+	return c.retryRequest(ctx, method, url, body, timeout, isRetriableCreate)
+}
+
+// retryRequest sends a request and retries it while retriable reports true for the error.
+func (c *PerfanaClient) retryRequest(ctx context.Context, method, url string, body io.Reader, timeout time.Duration, retriable func(error) bool) ([]byte, error) {
+	// Buffer the body so it can be sent again on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
 		}
-		if v != float64(int(v)) {
-			return 0, fmt.Errorf("must be an integer number of seconds")
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.doRequest(ctx, method, url, payload, timeout)
+		if err == nil || attempt >= c.config.MaxRetries || !retriable(err) {
+			return respBody, err
 		}
-		return int(v), nil
-	case string:
-		seconds, err := util.ParseISODurationToSeconds(v)
-		if err != nil {
-			return 0, err
+
+		wait := c.retryDelay(attempt, err)
+		c.log.Warn("request failed, retrying", "method", method, "url", url,
+			"attempt", attempt+1, "maxRetries", c.config.MaxRetries, "wait", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
 		}
-		return seconds, nil
-	default:
-		return 0, fmt.Errorf("unsupported type %T", raw)
 	}
 }
 
-// Shared helper method for HTTP requests
-func (c *PerfanaClient) makeRequest(method, url string, body io.Reader) ([]byte, error) {
+// doRequest sends a single HTTP request, limited to timeout when it is positive.
+func (c *PerfanaClient) doRequest(ctx context.Context, method, url string, payload []byte, timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
 
+	// Record whether the request reached the connection, for NetworkError.RequestSent
+	var sent atomic.Bool
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				sent.Store(true)
+			}
+		},
+	})
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.ApiKey)
-	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req)
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &NetworkError{Wrapped: err, RequestSent: sent.Load()}
 	}
 	defer resp.Body.Close()
+	c.log.Debug("perfana request", "method", method, "url", url, "status", resp.StatusCode, "latencyMs", time.Since(start).Milliseconds())
 
 	// Handle HTTP response errors
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body) // Read response body for better error messages
-		return nil, fmt.Errorf("HTTP error: %s (%d): %s", resp.Status, resp.StatusCode, string(body))
+		respErr := responseError(resp.StatusCode, string(body))
+		if httpErr, ok := respErr.(*HTTPError); ok {
+			httpErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+		return nil, respErr
 	}
 
 	// Read the response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, &NetworkError{Wrapped: err, RequestSent: true}
 	}
 
 	return respBody, nil
 }
 
+// setHeaders sets the authorization, content type and optional trace headers on a request.
+func (c *PerfanaClient) setHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+c.config.ApiKey)
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.TraceID != "" {
+		req.Header.Set("X-Trace-ID", c.config.TraceID)
+	}
+}
+
+// responseError maps an error status code to AuthError or HTTPError.
+func responseError(statusCode int, body string) error {
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return &AuthError{StatusCode: statusCode}
+	}
+	return &HTTPError{StatusCode: statusCode, Body: body}
+}
+
 // AbortTest sends an abort signal to the Perfana API for the given test run.
-func (c *PerfanaClient) AbortTest(testRunID string, additionalData map[string]interface{}) error {
+// Of opts, only Tags and Version are sent.
+func (c *PerfanaClient) AbortTest(testRunID string, opts TestEventOptions) error {
 	url := fmt.Sprintf("%s/api/test", c.config.ApiUrl)
 
 	message := PerfanaMessage{
-		TestRunID:       testRunID,
-		Workload:        c.config.Workload,
-		TestEnvironment: c.config.Environment,
-		SystemUnderTest: c.config.SystemUnderTest,
-		Completed:       false,
-		Abort:           true,
-	}
-
-	if tags, ok := additionalData["tags"]; ok {
-		message.Tags = tags.([]string)
-	}
-	if version, ok := additionalData["version"]; ok {
-		message.Version = version.(string)
+		TestRunID:          testRunID,
+		Workload:           c.config.Workload,
+		TestEnvironment:    c.config.Environment,
+		SystemUnderTest:    c.config.SystemUnderTest,
+		Completed:          false,
+		Abort:              true,
+		Tags:               opts.Tags,
+		Version:            opts.Version,
+		EventSchemaVersion: eventSchemaVersion(c.config),
 	}
 
 	reqBody, err := json.Marshal(message)
@@ -388,6 +552,90 @@ func (c *PerfanaClient) AbortTest(testRunID string, additionalData map[string]in
 	return err
 }
 
+// DeleteTestRun permanently deletes a test run. It returns a *NotFoundError when
+// the test run does not exist.
+func (c *PerfanaClient) DeleteTestRun(testRunID string) error {
+	url := fmt.Sprintf("%s/api/test/%s", c.config.ApiUrl, neturl.PathEscape(testRunID))
+
+	_, err := c.makeRequest("DELETE", url, nil)
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Resource: "test run", ID: testRunID}
+	}
+	return err
+}
+
+// TestRunPatch holds the test run metadata changed by UpdateTestRun. Zero fields
+// are left out of the request and keep their current value.
+type TestRunPatch struct {
+	Version           string     `json:"version,omitempty"`
+	CIBuildResultsURL string     `json:"CIBuildResultsUrl,omitempty"`
+	Annotations       string     `json:"annotations,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	Variables         []Variable `json:"variables,omitempty"`
+	DeepLinks         []DeepLink `json:"deepLinks,omitempty"`
+}
+
+// UpdateTestRun changes the metadata of a started test run, e.g. a version or CI
+// build URL that only becomes known during the run.
+func (c *PerfanaClient) UpdateTestRun(testRunID string, patch TestRunPatch) error {
+	url := fmt.Sprintf("%s/api/test/%s", c.config.ApiUrl, neturl.PathEscape(testRunID))
+
+	reqBody, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test run patch: %w", err)
+	}
+
+	_, err = c.makeRequest("PATCH", url, bytes.NewReader(reqBody))
+	return err
+}
+
+// PauseTestRun pauses a test run, e.g. to exclude a maintenance window from the timeline.
+func (c *PerfanaClient) PauseTestRun(testRunID string) error {
+	url := fmt.Sprintf("%s/api/test/%s/pause", c.config.ApiUrl, neturl.PathEscape(testRunID))
+
+	_, err := c.makeRequest("POST", url, nil)
+	return err
+}
+
+// ResumePausedTestRun resumes a test run paused with PauseTestRun.
+func (c *PerfanaClient) ResumePausedTestRun(testRunID string) error {
+	url := fmt.Sprintf("%s/api/test/%s/resume", c.config.ApiUrl, neturl.PathEscape(testRunID))
+
+	_, err := c.makeRequest("POST", url, nil)
+	return err
+}
+
+// RegressionMark is the PATCH payload that marks a test run as a regression.
+type RegressionMark struct {
+	RegressionMarked   bool     `json:"regressionMarked"`
+	RegressionMetrics  []string `json:"regressionMetrics,omitempty"`
+	RegressionSeverity string   `json:"regressionSeverity,omitempty"`
+}
+
+// TagTestRunAsRegression marks a test run as a regression on the given metrics.
+func (c *PerfanaClient) TagTestRunAsRegression(testRunID string, metrics []string) error {
+	return c.TagTestRunAsRegressionWithSeverity(testRunID, metrics, "")
+}
+
+// TagTestRunAsRegressionWithSeverity marks a test run as a regression on the given
+// metrics with a severity of LOW, MEDIUM or HIGH. An empty severity is omitted.
+func (c *PerfanaClient) TagTestRunAsRegressionWithSeverity(testRunID string, metrics []string, severity string) error {
+	url := fmt.Sprintf("%s/api/test/%s", c.config.ApiUrl, neturl.PathEscape(testRunID))
+
+	reqBody, err := json.Marshal(RegressionMark{
+		RegressionMarked:   true,
+		RegressionMetrics:  metrics,
+		RegressionSeverity: severity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal regression mark: %w", err)
+	}
+
+	_, err = c.makeRequest("PATCH", url, bytes.NewReader(reqBody))
+	return err
+}
+
 // GetTestRunStatus retrieves the status of a test run from the Perfana API.
 func (c *PerfanaClient) GetTestRunStatus(testRunID string) (*TestRunResult, error) {
 	url := fmt.Sprintf("%s/api/test-runs/%s", c.config.ApiUrl, testRunID)
@@ -405,6 +653,481 @@ func (c *PerfanaClient) GetTestRunStatus(testRunID string) (*TestRunResult, erro
 	return &result, nil
 }
 
+// Test run states reported by TestRunResult.State.
+const (
+	TestRunStateRunning   = "running"
+	TestRunStatePaused    = "paused"
+	TestRunStateCompleted = "completed"
+	TestRunStateAborted   = "aborted"
+)
+
+// TestRunStatus summarizes the state of a test run; see GetTestRunState.
+type TestRunStatus struct {
+	TestRunID            string `json:"testRunId"`
+	State                string `json:"state"`
+	StartTime            string `json:"startTime"`
+	EndTime              string `json:"endTime,omitempty"`
+	CompletionPercentage int    `json:"completionPercentage"`
+	Aborted              bool   `json:"aborted"`
+}
+
+// State summarizes the status flags of the test run in one of the TestRunState constants.
+func (r *TestRunResult) State() string {
+	switch {
+	case r.Abort:
+		return TestRunStateAborted
+	case r.Completed:
+		return TestRunStateCompleted
+	case r.Paused:
+		return TestRunStatePaused
+	default:
+		return TestRunStateRunning
+	}
+}
+
+// RunStatus returns the TestRunStatus summary of the test run.
+func (r *TestRunResult) RunStatus() TestRunStatus {
+	return TestRunStatus{
+		TestRunID:            r.TestRunID,
+		State:                r.State(),
+		StartTime:            r.StartTime,
+		EndTime:              r.EndTime,
+		CompletionPercentage: r.CompletionPercentage,
+		Aborted:              r.Abort,
+	}
+}
+
+// GetTestRunState retrieves the state of a test run. It reads the same
+// /api/test-runs/{id} resource as GetTestRunStatus, which carries the abort, paused
+// and completed flags the state is derived from; Perfana has no separate status endpoint.
+func (c *PerfanaClient) GetTestRunState(testRunID string) (*TestRunStatus, error) {
+	result, err := c.GetTestRunStatus(testRunID)
+	if err != nil {
+		return nil, err
+	}
+	status := result.RunStatus()
+	return &status, nil
+}
+
+// ListDeepLinks retrieves the deep links currently attached to a test run.
+func (c *PerfanaClient) ListDeepLinks(testRunID string) ([]DeepLink, error) {
+	url := fmt.Sprintf("%s/api/test/%s/deeplinks", c.config.ApiUrl, testRunID)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []DeepLink
+	if err := json.Unmarshal(resp, &links); err != nil {
+		return nil, fmt.Errorf("failed to parse deep links: %w", err)
+	}
+
+	return links, nil
+}
+
+// GetTestRunCost retrieves the estimated cost of a test run.
+func (c *PerfanaClient) GetTestRunCost(testRunID string) (*CostEstimate, error) {
+	url := fmt.Sprintf("%s/api/test/%s/cost", c.config.ApiUrl, testRunID)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cost CostEstimate
+	if err := json.Unmarshal(resp, &cost); err != nil {
+		return nil, fmt.Errorf("failed to parse cost estimate: %w", err)
+	}
+
+	return &cost, nil
+}
+
+// ListVariables retrieves the current variables of a test run.
+func (c *PerfanaClient) ListVariables(testRunID string) ([]Variable, error) {
+	url := fmt.Sprintf("%s/api/test/%s/variables", c.config.ApiUrl, testRunID)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []Variable
+	if err := json.Unmarshal(resp, &variables); err != nil {
+		return nil, fmt.Errorf("failed to parse variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// GetTestRunMetrics retrieves the current aggregated metric values of a test run, keyed by metric name.
+func (c *PerfanaClient) GetTestRunMetrics(testRunID string) (map[string]float64, error) {
+	url := fmt.Sprintf("%s/api/test/%s/metrics", c.config.ApiUrl, testRunID)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics map[string]float64
+	if err := json.Unmarshal(resp, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// Attachment is a file attached to a test run, such as the test plan script.
+// Content is sent base64-encoded.
+type Attachment struct {
+	Name    string `json:"name"`
+	Content []byte `json:"content"`
+}
+
+// UploadAttachment attaches a file to a test run.
+func (c *PerfanaClient) UploadAttachment(testRunID, name string, content []byte) error {
+	url := fmt.Sprintf("%s/api/test/%s/attachments", c.config.ApiUrl, testRunID)
+
+	reqBody, err := json.Marshal(Attachment{Name: name, Content: content})
+	if err != nil {
+		return fmt.Errorf("failed to marshal attachment: %w", err)
+	}
+
+	_, err = c.makeCreateRequest(c.baseCtx, "POST", url, bytes.NewReader(reqBody), c.requestTimeout)
+	return err
+}
+
+// ExportMetrics downloads the raw metrics of a test run in the given format (csv or json).
+// The response body is streamed; the caller must close the returned reader.
+func (c *PerfanaClient) ExportMetrics(testRunID string, format string) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s/api/test/%s/metrics/export?format=%s", c.config.ApiUrl, testRunID, neturl.QueryEscape(format))
+
+	// No request timeout: metric exports can be large
+	req, err := http.NewRequestWithContext(c.baseCtx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &NetworkError{Wrapped: err}
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, responseError(resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
+// GetTestRunAnnotations retrieves the timeline annotations of a test run.
+func (c *PerfanaClient) GetTestRunAnnotations(testRunID string) ([]Annotation, error) {
+	url := fmt.Sprintf("%s/api/test/%s/annotations", c.config.ApiUrl, testRunID)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []Annotation
+	if err := json.Unmarshal(resp, &annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations: %w", err)
+	}
+
+	return annotations, nil
+}
+
+// DeleteTestRunAnnotation removes a single annotation from a test run.
+func (c *PerfanaClient) DeleteTestRunAnnotation(testRunID, annotationID string) error {
+	url := fmt.Sprintf("%s/api/test/%s/annotations/%s", c.config.ApiUrl, neturl.PathEscape(testRunID), neturl.PathEscape(annotationID))
+
+	_, err := c.makeRequest("DELETE", url, nil)
+	return err
+}
+
+// DateRange limits a test run search to runs started within From and To.
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// TestRunQuery is the search request for SearchTestRuns. Empty fields are not filtered on.
+type TestRunQuery struct {
+	TextSearch string            `json:"textSearch,omitempty"`
+	Tags       []string          `json:"tags,omitempty"`
+	DateRange  *DateRange        `json:"dateRange,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Page       int               `json:"page,omitempty"`
+	PageSize   int               `json:"pageSize,omitempty"`
+}
+
+// TestRunSummary is a single test run in a search result.
+type TestRunSummary struct {
+	TestRunID       string    `json:"testRunId"`
+	SystemUnderTest string    `json:"systemUnderTest"`
+	TestEnvironment string    `json:"testEnvironment"`
+	Workload        string    `json:"workload"`
+	Version         string    `json:"version"`
+	Status          string    `json:"status"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Tags            []string  `json:"tags"`
+}
+
+// TestRunSearchResult is a page of test runs matching a TestRunQuery.
+type TestRunSearchResult struct {
+	Items    []TestRunSummary `json:"items"`
+	Total    int              `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+}
+
+// SearchTestRuns searches test runs by free text, tags, date range, variables, labels and status.
+func (c *PerfanaClient) SearchTestRuns(query TestRunQuery) (*TestRunSearchResult, error) {
+	url := fmt.Sprintf("%s/api/test/search", c.config.ApiUrl)
+
+	reqBody, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search query: %w", err)
+	}
+
+	resp, err := c.makeRequest("POST", url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	var result TestRunSearchResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse search result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// TestRunFilter selects the test runs returned by ListTestRuns. Empty fields are
+// not filtered on; a Limit of 0 returns all matching test runs.
+type TestRunFilter struct {
+	SystemUnderTest string
+	Environment     string
+	Workload        string
+	From            time.Time
+	To              time.Time
+	Limit           int
+}
+
+// listTestRunsPageSize is the number of test runs requested per page by ListTestRuns.
+const listTestRunsPageSize = 100
+
+// ListTestRuns lists test runs matching filter, newest first. It follows the pages
+// of the result until Limit test runs have been collected or no pages are left.
+func (c *PerfanaClient) ListTestRuns(filter TestRunFilter) ([]TestRunSummary, error) {
+	query := neturl.Values{}
+	if filter.SystemUnderTest != "" {
+		query.Set("systemUnderTest", filter.SystemUnderTest)
+	}
+	if filter.Environment != "" {
+		query.Set("testEnvironment", filter.Environment)
+	}
+	if filter.Workload != "" {
+		query.Set("workload", filter.Workload)
+	}
+	if !filter.From.IsZero() {
+		query.Set("from", filter.From.UTC().Format(time.RFC3339))
+	}
+	if !filter.To.IsZero() {
+		query.Set("to", filter.To.UTC().Format(time.RFC3339))
+	}
+	query.Set("pageSize", strconv.Itoa(listTestRunsPageSize))
+
+	var testRuns []TestRunSummary
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		url := fmt.Sprintf("%s/api/tests?%s", c.config.ApiUrl, query.Encode())
+
+		resp, err := c.makeRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result TestRunSearchResult
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse test runs: %w", err)
+		}
+
+		testRuns = append(testRuns, result.Items...)
+		if filter.Limit > 0 && len(testRuns) >= filter.Limit {
+			return testRuns[:filter.Limit], nil
+		}
+		if len(result.Items) < listTestRunsPageSize || (result.Total > 0 && len(testRuns) >= result.Total) {
+			return testRuns, nil
+		}
+	}
+}
+
+// Release groups the test runs (e.g. smoke, load, soak) of a software release.
+type Release struct {
+	ID              string    `json:"releaseId,omitempty"`
+	Version         string    `json:"version"`
+	SystemUnderTest string    `json:"systemUnderTest"`
+	TestRunIDs      []string  `json:"testRunIds"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// CreateRelease registers a release of the system under test with its test runs and returns the release ID.
+func (c *PerfanaClient) CreateRelease(version, systemUnderTest string, testRunIDs []string) (string, error) {
+	url := fmt.Sprintf("%s/api/releases", c.config.ApiUrl)
+
+	reqBody, err := json.Marshal(Release{
+		Version:         version,
+		SystemUnderTest: systemUnderTest,
+		TestRunIDs:      testRunIDs,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal release: %w", err)
+	}
+
+	resp, err := c.makeCreateRequest(c.baseCtx, "POST", url, bytes.NewReader(reqBody), c.requestTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		ReleaseID string `json:"releaseId"`
+	}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+
+	return created.ReleaseID, nil
+}
+
+// ListReleases retrieves the releases, optionally filtered on system under test.
+func (c *PerfanaClient) ListReleases(systemUnderTest string) ([]Release, error) {
+	url := fmt.Sprintf("%s/api/releases", c.config.ApiUrl)
+	if systemUnderTest != "" {
+		url += "?systemUnderTest=" + neturl.QueryEscape(systemUnderTest)
+	}
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	if err := json.Unmarshal(resp, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// DeploymentInfo describes a deployment of a service, which Perfana correlates with
+// the test runs in the same environment.
+type DeploymentInfo struct {
+	Version      string    `json:"version"`
+	Service      string    `json:"service"`
+	Environment  string    `json:"environment"`
+	DeployedAt   time.Time `json:"deployedAt"`
+	DeployedBy   string    `json:"deployedBy,omitempty"`
+	CommitSHA    string    `json:"commitSha,omitempty"`
+	ChangelogURL string    `json:"changelogUrl,omitempty"`
+}
+
+// NotifyDeployment registers a deployment and returns the deployment ID.
+func (c *PerfanaClient) NotifyDeployment(deployment DeploymentInfo) (string, error) {
+	url := fmt.Sprintf("%s/api/deployments", c.config.ApiUrl)
+
+	reqBody, err := json.Marshal(deployment)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal deployment: %w", err)
+	}
+
+	resp, err := c.makeCreateRequest(c.baseCtx, "POST", url, bytes.NewReader(reqBody), c.requestTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		DeploymentID string `json:"deploymentId"`
+	}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return "", fmt.Errorf("failed to parse deployment response: %w", err)
+	}
+
+	return created.DeploymentID, nil
+}
+
+// WebhookConfig is a server-side webhook that Perfana calls on test run events.
+type WebhookConfig struct {
+	ID          string            `json:"id,omitempty"`
+	URL         string            `json:"url"`
+	Events      []string          `json:"events"`
+	Secret      string            `json:"secret,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+// CreateWebhook registers a server-side webhook and returns its ID.
+func (c *PerfanaClient) CreateWebhook(hook WebhookConfig) (string, error) {
+	url := fmt.Sprintf("%s/api/webhooks", c.config.ApiUrl)
+
+	reqBody, err := json.Marshal(hook)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal webhook: %w", err)
+	}
+
+	resp, err := c.makeCreateRequest(c.baseCtx, "POST", url, bytes.NewReader(reqBody), c.requestTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	var created WebhookConfig
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return "", fmt.Errorf("failed to parse webhook response: %w", err)
+	}
+
+	return created.ID, nil
+}
+
+// ListWebhooks retrieves the registered server-side webhooks.
+func (c *PerfanaClient) ListWebhooks() ([]WebhookConfig, error) {
+	url := fmt.Sprintf("%s/api/webhooks", c.config.ApiUrl)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []WebhookConfig
+	if err := json.Unmarshal(resp, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+
+	return hooks, nil
+}
+
+// DeleteWebhook removes a server-side webhook.
+func (c *PerfanaClient) DeleteWebhook(webhookID string) error {
+	url := fmt.Sprintf("%s/api/webhooks/%s", c.config.ApiUrl, neturl.PathEscape(webhookID))
+
+	_, err := c.makeRequest("DELETE", url, nil)
+	return err
+}
+
+// TestWebhook asks Perfana to send a test delivery to a server-side webhook.
+func (c *PerfanaClient) TestWebhook(webhookID string) error {
+	url := fmt.Sprintf("%s/api/webhooks/%s/test", c.config.ApiUrl, neturl.PathEscape(webhookID))
+
+	_, err := c.makeRequest("POST", url, nil)
+	return err
+}
+
 // GetCheckResults retrieves SLO check results for a completed test run.
 func (c *PerfanaClient) GetCheckResults(testRunID, system, environment, workload string) ([]CheckResult, error) {
 	url := fmt.Sprintf("%s/api/test-runs/%s/check-results?system=%s&environment=%s&workload=%s",
@@ -445,6 +1168,138 @@ func (c *PerfanaClient) GetAdaptConclusion(testRunID string) (*AdaptConclusion,
 	return &result, nil
 }
 
+// TestRunExport bundles the status, SLO check results and adapt conclusion of a test run.
+type TestRunExport struct {
+	TestRun         *TestRunResult   `json:"testRun"`
+	CheckResults    []CheckResult    `json:"checkResults"`
+	AdaptConclusion *AdaptConclusion `json:"adaptConclusion,omitempty"`
+}
+
+// ExportTestRun collects the status, check results and adapt conclusion of a test run
+// into a single TestRunExport.
+func (c *PerfanaClient) ExportTestRun(testRunID string) (*TestRunExport, error) {
+	testRun, err := c.GetTestRunStatus(testRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test run: %w", err)
+	}
+
+	checks, err := c.GetCheckResults(testRunID, testRun.SystemsUnderTest.Name, testRun.TestEnvironment, testRun.Workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check results: %w", err)
+	}
+
+	adapt, err := c.GetAdaptConclusion(testRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adapt conclusion: %w", err)
+	}
+
+	return &TestRunExport{
+		TestRun:         testRun,
+		CheckResults:    checks,
+		AdaptConclusion: adapt,
+	}, nil
+}
+
+// GetAPIInfo retrieves the server version and supported features from /api/info.
+func (c *PerfanaClient) GetAPIInfo() (*APIInfo, error) {
+	url := fmt.Sprintf("%s/api/info", c.config.ApiUrl)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info APIInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse api info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// GetServerVersion retrieves the version of the Perfana server from /api/version.
+func (c *PerfanaClient) GetServerVersion() (string, error) {
+	url := fmt.Sprintf("%s/api/version", c.config.ApiUrl)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(resp, &response); err != nil {
+		return "", fmt.Errorf("failed to parse server version: %w", err)
+	}
+
+	return response.Version, nil
+}
+
+// CheckHealth performs a GET request to /api/health to confirm the Perfana
+// server is reachable and accepts the configured credentials.
+func (c *PerfanaClient) CheckHealth() error {
+	url := fmt.Sprintf("%s/api/health", c.config.ApiUrl)
+
+	_, err := c.makeRequest("GET", url, nil)
+	return err
+}
+
+// SupportsFeature reports whether the server advertised the given feature.
+// When capabilities were not discovered, it returns true so callers attempt the request.
+func (c *PerfanaClient) SupportsFeature(feature string) bool {
+	if c.apiInfo == nil {
+		return true
+	}
+	for _, f := range c.apiInfo.SupportedFeatures {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServerTime retrieves the current time of the Perfana server from /api/time.
+func (c *PerfanaClient) GetServerTime() (time.Time, error) {
+	url := fmt.Sprintf("%s/api/time", c.config.ApiUrl)
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var response struct {
+		Time time.Time `json:"time"`
+	}
+	if err := json.Unmarshal(resp, &response); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse server time: %w", err)
+	}
+
+	return response.Time, nil
+}
+
+// FetchConfig downloads the test configuration stored in Perfana for the given
+// system under test, workload and environment.
+func (c *PerfanaClient) FetchConfig(systemUnderTest, workload, environment string) (*ServerConfig, error) {
+	query := neturl.Values{}
+	query.Set("sut", systemUnderTest)
+	query.Set("workload", workload)
+	query.Set("environment", environment)
+	url := fmt.Sprintf("%s/api/config?%s", c.config.ApiUrl, query.Encode())
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ServerConfig
+	if err := json.Unmarshal(resp, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+
+	return &config, nil
+}
+
 // GetDefaultOrganizationID returns the ID of the first organization available to the API key.
 func (c *PerfanaClient) GetDefaultOrganizationID() (string, error) {
 	url := fmt.Sprintf("%s/api/organizations", c.config.ApiUrl)
@@ -464,6 +1319,15 @@ func (c *PerfanaClient) GetDefaultOrganizationID() (string, error) {
 	return orgs[0].ID, nil
 }
 
+// HTTPClientWithTimeout returns an HTTP client sharing the transport (including
+// mTLS settings) of the Perfana client, with its own timeout.
+func (c *PerfanaClient) HTTPClientWithTimeout(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: c.httpClient.Transport,
+		Timeout:   timeout,
+	}
+}
+
 // AppUrl returns the configured UI application URL.
 func (c *PerfanaClient) AppUrl() string {
 	return c.config.AppUrl
@@ -571,45 +1435,52 @@ func (c *PerfanaClient) SendConfigJSON(testRunID, systemUnderTest, testEnvironme
 	return err
 }
 
-// sendPerfanaEvent sends a PerfanaEvent to the /api/events endpoint.
-// It returns an error if the request fails or if the response status is non-200,
-// along with the server response for non-200 statuses.
-func (c *PerfanaClient) SendPerfanaEvent(event PerfanaEvent) (string, error) {
+// SendPerfanaEvent sends a PerfanaEvent to the /api/events endpoint and returns the
+// response of Perfana. It returns an error if the request fails or if the response
+// status is non-200. The request is bound to ctx only; the client's request timeout
+// does not apply.
+func (c *PerfanaClient) SendPerfanaEvent(ctx context.Context, event PerfanaEvent) (*EventResponse, error) {
 	url := fmt.Sprintf("%s/api/events", c.config.ApiUrl)
 
 	// Marshal the event struct into JSON
 	reqBody, err := json.Marshal(event)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %v", err)
+		return nil, fmt.Errorf("failed to marshal JSON: %v", err)
 	}
 
-	// Create a context with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
 	// Create the HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.config.ApiKey)
-	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req)
 
 	// Perform the request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %v", err)
+		return nil, fmt.Errorf("failed to execute request: %w", &NetworkError{Wrapped: err})
 	}
 	defer resp.Body.Close()
 
+	body, readErr := io.ReadAll(resp.Body)
+
 	// Handle non-200 response status codes
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body for error details
-		return string(body), fmt.Errorf("non-200 response received: %s (%d)", resp.Status, resp.StatusCode)
+		return nil, fmt.Errorf("non-200 response received: %w", responseError(resp.StatusCode, string(body)))
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read response: %w", &NetworkError{Wrapped: readErr})
 	}
 
-	// Successful response
-	return "Event sent successfully.", nil
+	// Successful response; older Perfana versions reply with an empty or non-JSON body
+	response := &EventResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		response = &EventResponse{}
+	}
+	if response.Message == "" {
+		response.Message = "Event sent successfully."
+	}
+	return response, nil
 }
@@ -0,0 +1,83 @@
+package perfana_client
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryBackoff is the initial back-off when RetryBackoff is not configured.
+const defaultRetryBackoff = time.Second
+
+// maxRetryBackoff caps the exponential back-off.
+const maxRetryBackoff = time.Minute
+
+// isRetriable reports whether a failed request is worth retrying: network errors,
+// 5xx responses and 429 Too Many Requests. Other 4xx responses are not retried.
+func isRetriable(err error) bool {
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// isRetriableCreate reports whether a failed request that creates a resource is worth
+// retrying: network errors before the request was sent and 429 Too Many Requests. Any
+// other failure may come after the server created the resource.
+func isRetriableCreate(err error) bool {
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		return !netErr.RequestSent
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// retryDelay returns the wait before the next attempt: the Retry-After delay if the
+// server sent one, otherwise exponential back-off with jitter.
+func (c *PerfanaClient) retryDelay(attempt int, err error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+		return httpErr.RetryAfter
+	}
+
+	backoff := c.config.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	delay := backoff
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	// Jitter: wait between half and the full back-off
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
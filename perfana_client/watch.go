@@ -0,0 +1,81 @@
+package perfana_client
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"time"
+)
+
+// defaultWatchInterval is the polling interval of Watch when WatchInterval is not set.
+const defaultWatchInterval = 5 * time.Second
+
+// Event is an event recorded by Perfana for a test run, as returned by /api/events.
+type Event struct {
+	TestRunID   string    `json:"testRunId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// ListEvents retrieves the events of a test run recorded after since. A zero since
+// returns all events.
+func (c *PerfanaClient) ListEvents(testRunID string, since time.Time) ([]Event, error) {
+	query := neturl.Values{}
+	query.Set("testRunId", testRunID)
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	url := fmt.Sprintf("%s/api/events?%s", c.config.ApiUrl, query.Encode())
+
+	resp, err := c.makeRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if err := json.Unmarshal(resp, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Watch polls the events of a test run every WatchInterval and calls handler for each
+// event recorded after since, in the order returned by the server. It returns nil once
+// the test run has completed or was aborted and the remaining events were handled.
+func (c *PerfanaClient) Watch(testRunID string, since time.Time, handler func(Event)) error {
+	interval := c.config.WatchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	for {
+		// Check the status first so events recorded just before completion are not missed
+		status, err := c.GetTestRunStatus(testRunID)
+		if err != nil {
+			return err
+		}
+
+		events, err := c.ListEvents(testRunID, since)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			// The since filter may be inclusive; skip events that were already handled
+			if !since.IsZero() && !e.Timestamp.After(since) {
+				continue
+			}
+			handler(e)
+			if e.Timestamp.After(since) {
+				since = e.Timestamp
+			}
+		}
+
+		if status.Completed || status.Abort {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"perfana-cli/logger"
+)
+
+// abortFileExists reports whether AbortFile has appeared.
+func (s *EventScheduler) abortFileExists() bool {
+	_, err := os.Stat(s.AbortFile)
+	return err == nil
+}
+
+// removeAbortFile removes AbortFile, so it does not abort the next run.
+func (s *EventScheduler) removeAbortFile() {
+	if err := os.Remove(s.AbortFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("failed to remove abort file", "file", s.AbortFile, "err", err)
+	}
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// annotationsTemplateData is the context of the AnnotationsTemplateFile template.
+type annotationsTemplateData struct {
+	TestRunID    string
+	Elapsed      time.Duration
+	Tick         int
+	SuccessCount int
+	ErrorCount   int
+}
+
+// renderAnnotationsTemplate re-reads AnnotationsTemplateFile and renders it with the
+// current state of the run, so edits to the file take effect on the next keep-alive.
+func (s *EventScheduler) renderAnnotationsTemplate() (string, error) {
+	content, err := os.ReadFile(s.AnnotationsTemplateFile)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("annotations").Parse(string(content))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	err = tmpl.Execute(&b, annotationsTemplateData{
+		TestRunID:    s.testRunID,
+		Elapsed:      time.Since(s.loopStart).Truncate(time.Second),
+		Tick:         s.keepAliveTicks,
+		SuccessCount: s.stats.KeepAlivesSent,
+		ErrorCount:   s.stats.KeepAliveErrors,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
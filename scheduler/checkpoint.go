@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"fmt"
+	"perfana-cli/logger"
+	"strings"
+	"time"
+
+	"perfana-cli/perfana_client"
+)
+
+// checkpointTag is added to the tags of every checkpoint event.
+const checkpointTag = "checkpoint"
+
+// checkpointTemplateData is the context of the CheckpointNameTemplate template.
+type checkpointTemplateData struct {
+	Checkpoint int
+	Elapsed    time.Duration
+	TestRunID  string
+}
+
+// sendCheckpointEvent posts the checkpoint-th timeline checkpoint, titled by
+// CheckpointNameTemplate, to Perfana.
+func (s *EventScheduler) sendCheckpointEvent(checkpoint int) {
+	elapsed := time.Since(s.loopStart).Truncate(time.Second)
+
+	var title strings.Builder
+	if err := s.CheckpointNameTemplate.Execute(&title, checkpointTemplateData{
+		Checkpoint: checkpoint,
+		Elapsed:    elapsed,
+		TestRunID:  s.testRunID,
+	}); err != nil {
+		logger.Warn("failed to render checkpoint name", "checkpoint", checkpoint, "err", err)
+		return
+	}
+
+	tags := append(append([]string{}, s.TestContext.Tags...), checkpointTag)
+	logger.Info("checkpoint reached", "checkpoint", checkpoint, "elapsed", elapsed)
+	s.sendPerfanaEvent(perfana_client.PerfanaEvent{
+		TestRunID:       s.testRunID,
+		SystemUnderTest: s.TestContext.SystemUnderTest,
+		TestEnvironment: s.TestContext.Environment,
+		Workload:        s.TestContext.Workload,
+		Title:           strings.TrimSpace(title.String()),
+		Description:     fmt.Sprintf("Checkpoint %d after %s", checkpoint, elapsed),
+		Tags:            tags,
+	})
+}
@@ -1,6 +1,10 @@
 package scheduler
 
-import "perfana-cli/perfana_client"
+import (
+	"sort"
+
+	"perfana-cli/perfana_client"
+)
 
 // TestContext holds the runtime context passed to each event lifecycle method.
 type TestContext struct {
@@ -19,6 +23,32 @@ type TestContext struct {
 	Client              *perfana_client.PerfanaClient
 }
 
+// TestEventOptions returns the optional test event fields for this context. The
+// variables are sorted by placeholder.
+func (ctx TestContext) TestEventOptions() perfana_client.TestEventOptions {
+	opts := perfana_client.TestEventOptions{
+		Version:             ctx.Version,
+		CIBuildResultsURL:   ctx.BuildResultsUrl,
+		AnalysisStartOffset: ctx.AnalysisStartOffset,
+		Duration:            ctx.Duration,
+		Annotations:         ctx.Annotations,
+		Tags:                ctx.Tags,
+		DeepLinks:           ctx.DeepLinks,
+	}
+	if len(ctx.Variables) > 0 {
+		placeholders := make([]string, 0, len(ctx.Variables))
+		for k := range ctx.Variables {
+			placeholders = append(placeholders, k)
+		}
+		sort.Strings(placeholders)
+		opts.Variables = make([]perfana_client.Variable, 0, len(placeholders))
+		for _, k := range placeholders {
+			opts.Variables = append(opts.Variables, perfana_client.Variable{Placeholder: k, Value: ctx.Variables[k]})
+		}
+	}
+	return opts
+}
+
 // Event defines the lifecycle interface for test events.
 // Each event participates in the scheduler's orchestrated test lifecycle.
 type Event interface {
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"fmt"
+	"perfana-cli/logger"
+	"perfana-cli/perfana_client"
+	"time"
+)
+
+// extendTestDuration adds TimeoutExtendStep to the planned test duration, so the
+// progress bar and later test events reflect it, and posts an event with the new
+// expected end time.
+func (s *EventScheduler) extendTestDuration(testEnd time.Time) {
+	stepSec := int(s.TimeoutExtendStep / time.Second)
+	s.TestDurationSec += stepSec
+	if s.TestContext.Duration > 0 {
+		s.TestContext.Duration += stepSec
+	}
+	logger.Info("test duration extended", "step", s.TimeoutExtendStep, "expectedEnd", testEnd.Format(time.RFC3339))
+
+	s.sendPerfanaEvent(perfana_client.PerfanaEvent{
+		TestRunID:       s.testRunID,
+		SystemUnderTest: s.TestContext.SystemUnderTest,
+		TestEnvironment: s.TestContext.Environment,
+		Workload:        s.TestContext.Workload,
+		Title:           "Test duration extended",
+		Description:     fmt.Sprintf("Extended by %s, expected end time %s", s.TimeoutExtendStep, testEnd.Format(time.RFC3339)),
+		Tags:            s.TestContext.Tags,
+	})
+}
@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"perfana-cli/logger"
+)
+
+// pingHeartbeat sends a GET to the heartbeat URL matching the keep-alive outcome.
+// Heartbeat failures are logged and never affect the test run.
+func (s *EventScheduler) pingHeartbeat(keepAliveErr error) {
+	url := s.HeartbeatURL
+	if keepAliveErr != nil {
+		url = s.HeartbeatFailureURL
+	}
+	if url == "" {
+		return
+	}
+
+	client := s.HeartbeatClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Get(url)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			err = fmt.Errorf("HTTP error: %s", resp.Status)
+		}
+	}
+	if err != nil {
+		logger.Warn("heartbeat ping failed", "url", url, "err", err)
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"perfana-cli/logger"
+	"time"
+)
+
+// JUnitTestSuite is the root element of the JUnit XML report written to JUnitFile.
+type JUnitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single SLO check in the JUnit XML report.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure marks a JUnitTestCase whose SLO check did not meet its requirement.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the SLO check results of the current test run to JUnitFile.
+// Without check results (e.g. when they could not be fetched) the suite has no test cases.
+func (s *EventScheduler) writeJUnitReport() error {
+	suite := JUnitTestSuite{
+		Name:      fmt.Sprintf("%s/%s/%s", s.TestContext.SystemUnderTest, s.TestContext.Environment, s.TestContext.Workload),
+		Time:      time.Since(s.loopStart).Seconds(),
+		Timestamp: s.loopStart.UTC().Format("2006-01-02T15:04:05"),
+	}
+	for _, c := range s.checkResults {
+		tc := JUnitTestCase{
+			Name:      c.PanelTitle,
+			ClassName: c.DashboardLabel,
+		}
+		if !c.MeetsRequirement {
+			message := fmt.Sprintf("%s: avg %s %s, requirement %s %.4g %s",
+				c.PanelTitle, c.PanelAverage, c.MetricUnit, c.Requirement.Operator, c.Requirement.Value, c.MetricUnit)
+			tc.Failure = &JUnitFailure{Message: message, Type: "SLOCheckFailed", Text: c.Message}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(s.JUnitFile, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	logger.Info("JUnit report written", "file", s.JUnitFile, "tests", suite.Tests, "failures", suite.Failures)
+	return nil
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// RunOutput is the JSON document written to OutputFile with OutputFileFormat "json".
+type RunOutput struct {
+	TestRunID       string    `json:"testRunId"`
+	SystemUnderTest string    `json:"systemUnderTest"`
+	TestEnvironment string    `json:"testEnvironment"`
+	Workload        string    `json:"workload"`
+	Version         string    `json:"version,omitempty"`
+	URL             string    `json:"url,omitempty"`
+	StartTime       time.Time `json:"startTime"`
+}
+
+// writeOutputFile writes the testRunId of the current test run to OutputFile.
+func (s *EventScheduler) writeOutputFile() error {
+	var data []byte
+	switch s.OutputFileFormat {
+	case "", "text":
+		data = []byte(s.testRunID + "\n")
+	case "json":
+		var err error
+		data, err = json.MarshalIndent(RunOutput{
+			TestRunID:       s.testRunID,
+			SystemUnderTest: s.TestContext.SystemUnderTest,
+			TestEnvironment: s.TestContext.Environment,
+			Workload:        s.TestContext.Workload,
+			Version:         s.TestContext.Version,
+			URL:             s.reportURL(),
+			StartTime:       s.stats.StartTime.UTC(),
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown output file format %q", s.OutputFileFormat)
+	}
+	return os.WriteFile(s.OutputFile, data, 0644)
+}
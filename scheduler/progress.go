@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"perfana-cli/util"
+	"strings"
+	"time"
+)
+
+// progressBarWidth is the number of characters between the brackets of the progress bar.
+const progressBarWidth = 30
+
+// showProgress reports whether the progress bar is drawn. Never with JSON on stdout:
+// the bar's control sequences would end up between the JSON lines.
+func (s *EventScheduler) showProgress() bool {
+	return s.ProgressBar && !s.StructuredStdout && s.SummaryFormat != "json"
+}
+
+// printProgress redraws the single-line progress bar for the elapsed part of the test duration.
+func (s *EventScheduler) printProgress(elapsed time.Duration) {
+	total := time.Duration(s.TestDurationSec) * time.Second
+	if total <= 0 {
+		return
+	}
+	if elapsed > total {
+		elapsed = total
+	}
+
+	fraction := float64(elapsed) / float64(total)
+	filled := int(fraction * progressBarWidth)
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	// \r returns to the start of the line, \033[2K clears it
+	fmt.Fprintf(util.NewAnsiWriter(os.Stdout, s.NoColor), "\r\033[2K[%s] %3d%% (%s elapsed / %s total)",
+		bar, int(fraction*100), formatClock(elapsed), formatClock(total))
+}
+
+// endProgress moves the cursor past the progress bar line.
+func (s *EventScheduler) endProgress() {
+	fmt.Fprintln(os.Stdout)
+}
+
+// formatClock formats a duration as mm:ss, or h:mm:ss from one hour.
+func formatClock(d time.Duration) string {
+	seconds := int(d.Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
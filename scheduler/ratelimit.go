@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"perfana-cli/logger"
+	"sync"
+	"time"
+
+	"perfana-cli/perfana_client"
+)
+
+// eventRateLimiter sends Perfana events at most once per interval. Excess events are
+// queued; when the queue is full, the oldest queued event is discarded.
+type eventRateLimiter struct {
+	ctx       context.Context
+	client    *perfana_client.PerfanaClient
+	interval  time.Duration
+	queueSize int
+
+	mu      sync.Mutex
+	queue   []perfana_client.PerfanaEvent
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newEventRateLimiter(ctx context.Context, client *perfana_client.PerfanaClient, interval time.Duration, queueSize int) *eventRateLimiter {
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	l := &eventRateLimiter{
+		ctx:       ctx,
+		client:    client,
+		interval:  interval,
+		queueSize: queueSize,
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// enqueue queues an event for sending, discarding the oldest queued event when the queue is full.
+func (l *eventRateLimiter) enqueue(event perfana_client.PerfanaEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queue) >= l.queueSize {
+		logger.Warn("event queue full, discarding oldest event", "event", l.queue[0].Title)
+		l.queue = l.queue[1:]
+	}
+	l.queue = append(l.queue, event)
+}
+
+// stop sends the remaining queued events at the configured rate and waits until done.
+func (l *eventRateLimiter) stop() {
+	close(l.done)
+	<-l.stopped
+}
+
+func (l *eventRateLimiter) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	done := l.done
+	draining := false
+	for {
+		select {
+		case <-done:
+			draining = true
+			done = nil
+			continue
+		case <-ticker.C:
+		}
+
+		event, ok := l.next()
+		if !ok {
+			if draining {
+				return
+			}
+			continue
+		}
+		ctx, cancel := context.WithTimeout(l.ctx, perfanaRequestTimeout)
+		if _, err := l.client.SendPerfanaEvent(ctx, event); err != nil {
+			logger.Warn("failed to post event", "event", event.Title, "err", err)
+		}
+		cancel()
+	}
+}
+
+// next pops the oldest queued event.
+func (l *eventRateLimiter) next() (perfana_client.PerfanaEvent, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queue) == 0 {
+		return perfana_client.PerfanaEvent{}, false
+	}
+	event := l.queue[0]
+	l.queue = l.queue[1:]
+	return event, true
+}
@@ -1,12 +1,21 @@
 package scheduler
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"net/http"
 	"perfana-cli/logger"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
+	"sync"
 	"syscall"
+	"text/template"
 	"time"
 
 	"perfana-cli/perfana_client"
@@ -15,9 +24,13 @@ import (
 type stopReason int
 
 const (
-	stopNormal   stopReason = iota
-	stopSignal              // SIGINT / SIGTERM
-	stopUIAbort             // abort flag set on test run via Perfana UI
+	stopNormal    stopReason = iota
+	stopSignal               // SIGINT / SIGTERM
+	stopUIAbort              // abort flag set on test run via Perfana UI
+	stopTimeout              // test duration reached
+	stopRequested            // Abort called by an embedding program
+	stopSLABreach            // SLA violated during the run
+	stopCompleted            // test run completed by another client, e.g. run stop
 )
 
 // EventScheduler orchestrates the full test lifecycle:
@@ -31,20 +44,236 @@ type EventScheduler struct {
 	TestContext          TestContext
 	FailOnError          bool
 
-	testRunID string
+	// ReportDeepLinkOnComplete adds a deep link to the Perfana report to the completion event.
+	ReportDeepLinkOnComplete bool
+
+	// ExportFile, when set, receives a JSON export of the test run after results are checked.
+	ExportFile string
+
+	// JUnitFile, when set, receives a JUnit XML report with one test case per SLO check
+	// after results are checked.
+	JUnitFile string
+
+	// IgnoreAssertionFailures makes Run succeed when the SLO checks or the adapt
+	// analysis of a completed test run fail; the results are still reported.
+	IgnoreAssertionFailures bool
+
+	// TestRunURLFile, when set, receives the Perfana dashboard URL of the test run after Init.
+	TestRunURLFile string
+
+	// OutputFile, when set, receives the testRunId after Init, as a single line or, with
+	// OutputFileFormat "json", as a JSON RunOutput document.
+	OutputFile       string
+	OutputFileFormat string
+
+	// TestPlanFile, when set, is uploaded as an attachment named TestPlanName
+	// (default: the file's basename) after Init. Upload failures are logged only.
+	TestPlanFile string
+	TestPlanName string
+
+	// TestRunIDFormat, when set, must match the testRunId returned by Init;
+	// otherwise the run is aborted.
+	TestRunIDFormat *regexp.Regexp
+
+	// PostStartCheck, when set, runs after the initial test event. An error aborts the run.
+	PostStartCheck func(ctx TestContext) error
+
+	// RampUpCompleteEventTitle, when set, is the title of an event posted to Perfana
+	// once the ramp-up window (analysis start offset) has elapsed.
+	RampUpCompleteEventTitle string
+
+	// CheckpointInterval, when positive, posts a checkpoint event tagged "checkpoint" to
+	// Perfana at this interval. CheckpointNameTemplate renders the event title from the
+	// fields .Checkpoint (1-based), .Elapsed and .TestRunID.
+	CheckpointInterval     time.Duration
+	CheckpointNameTemplate *template.Template
+
+	// NoCompleteOnTimeout exits without sending the completion event when the
+	// test duration is reached, leaving completion to an external orchestrator.
+	NoCompleteOnTimeout bool
+
+	// StopOnExternalCompletion ends the run without sending events once the test run
+	// is completed by another client, e.g. 'run stop' for a run started with --attach.
+	StopOnExternalCompletion bool
+
+	// UseServerTime records the skew between the local clock and the Perfana server
+	// as the clockSkewMs variable after Init.
+	UseServerTime bool
+
+	// KeepAliveFailureRate is the probability (0.0–1.0) that a keep-alive send fails
+	// with a synthetic error, for fault injection testing.
+	KeepAliveFailureRate float64
+
+	// HeartbeatURL receives a GET after each successful keep-alive,
+	// HeartbeatFailureURL after each failed one (e.g. healthchecks.io ping URLs).
+	HeartbeatURL        string
+	HeartbeatFailureURL string
+	// HeartbeatClient is used for heartbeat pings; defaults to http.DefaultClient.
+	HeartbeatClient *http.Client
+
+	// StructuredStdout writes each lifecycle event as a JSON line to stdout.
+	StructuredStdout bool
+
+	// SlackWebhookURL, when set, receives a message with the run outcome on completion or abort.
+	// SlackMessageTemplate overrides the message text; it supports the placeholders
+	// {testRunId}, {status}, {duration} and {reportUrl}.
+	SlackWebhookURL      string
+	SlackMessageTemplate string
+
+	// TraceID is the external distributed trace ID the run is linked to, shown in the summary.
+	TraceID string
+
+	// PrintKeepAliveCount prints the number of successful and failed keep-alives when the run ends.
+	PrintKeepAliveCount bool
+
+	// SummaryOnComplete prints a run summary after the final event.
+	SummaryOnComplete bool
+	// SummaryFormat is the summary output format: "text" (default) or "json".
+	SummaryFormat string
+
+	// EventRateInterval, when set, is the minimum interval between Perfana events.
+	// Excess events are queued up to EventQueueSize; when the queue is full the
+	// oldest queued event is discarded.
+	EventRateInterval time.Duration
+	EventQueueSize    int
+
+	// SLAs, when set, are evaluated against the test run metrics every SLAPollInterval
+	// during the run; a violated SLA aborts the run and Run returns ErrSLABreached.
+	SLAs            []perfana_client.SLADefinition
+	SLAPollInterval time.Duration
+
+	// TimeoutExtendSignal, when set, extends the test duration by TimeoutExtendStep each
+	// time the signal is received, and posts an event with the new expected end time.
+	TimeoutExtendSignal os.Signal
+	TimeoutExtendStep   time.Duration
+
+	// ProgressBar renders a single-line progress bar for the test duration on each keep-alive tick.
+	// It is ignored when stdout carries JSON (StructuredStdout or SummaryFormat "json").
+	ProgressBar bool
+	// NoColor strips ANSI escape codes from the progress output.
+	NoColor bool
+
+	// AnnotationsTemplateFile, when set, is a text/template file that is re-read and
+	// rendered on each keep-alive; the result is sent as the keep-alive annotations.
+	// The template has the fields .TestRunID, .Elapsed, .Tick, .SuccessCount and .ErrorCount.
+	AnnotationsTemplateFile string
+
+	// Context, when set, bounds the Init, test event and Perfana event requests;
+	// cancelling it cancels those requests in flight and aborts the run like a signal.
+	// Defaults to context.Background().
+	Context context.Context
+
+	// AbortFile, when set, is polled every AbortFilePollInterval during the run; when
+	// the file appears the run is aborted as on SIGTERM. The file is removed when Run returns.
+	AbortFile             string
+	AbortFilePollInterval time.Duration
+
+	// NoSignalHandler skips SIGINT/SIGTERM handling, for embedding in programs that
+	// handle signals themselves. The embedding program calls Abort to stop the run.
+	// A Client created with perfana_client.WithoutSignalHandler has the same effect.
+	NoSignalHandler bool
+
+	testRunID      string
+	stats          RunStats
+	eventLimiter   *eventRateLimiter
+	abortOnce      sync.Once
+	abortChan      chan string
+	abortReason    string
+	breachedSLA    *perfana_client.SLADefinition
+	checkResults   []perfana_client.CheckResult
+	loopStart      time.Time
+	keepAliveTicks int
+}
+
+// Abort requests the running test to be aborted, e.g. from the signal handler of an
+// embedding program. The run is aborted in Perfana and Run returns an error.
+func (s *EventScheduler) Abort(reason string) {
+	select {
+	case s.abortRequests() <- reason:
+	default:
+		// an abort is already pending
+	}
+}
+
+// abortRequests returns the channel that delivers Abort requests to the keep-alive loop.
+func (s *EventScheduler) abortRequests() chan string {
+	s.abortOnce.Do(func() {
+		s.abortChan = make(chan string, 1)
+	})
+	return s.abortChan
 }
 
 // Run executes the full event lifecycle. It blocks until the test completes,
 // is aborted by signal, or a fatal error occurs.
 func (s *EventScheduler) Run() error {
+	s.stats.StartTime = time.Now()
+	err := s.run()
+	s.stats.EndTime = time.Now()
+	if s.AbortFile != "" {
+		s.removeAbortFile()
+	}
+
+	if s.stats.Status == "" {
+		s.stats.Status = "completed"
+		if err != nil {
+			s.stats.Status = "failed"
+		}
+	}
+	if s.SummaryOnComplete && s.testRunID != "" {
+		s.printSummary()
+	}
+	if s.PrintKeepAliveCount {
+		fmt.Fprintf(os.Stdout, "Keep-alive summary: %d successful, %d failed\n", s.stats.KeepAlivesSent, s.stats.KeepAliveErrors)
+	}
+	if s.testRunID != "" {
+		s.notifySlack()
+	}
+	return err
+}
+
+func (s *EventScheduler) run() error {
 	// 1. Initialize Perfana session
-	testRunID, err := s.Client.Init()
+	ctx, cancel := s.requestContext()
+	testRunID, err := s.Client.Init(ctx)
+	cancel()
 	if err != nil {
 		return fmt.Errorf("perfana init failed: %w", err)
 	}
 	s.testRunID = testRunID
 	s.TestContext.TestRunID = testRunID
 	logger.Info("session initialized", "testRunId", testRunID)
+	s.emitStructured("started", nil)
+
+	if s.TestRunIDFormat != nil && !s.TestRunIDFormat.MatchString(testRunID) {
+		if err := s.Client.AbortTest(s.testRunID, s.testEventOptions()); err != nil {
+			logger.Warn("failed to send abort", "err", err)
+		}
+		return fmt.Errorf("testRunId %q does not match required format %q", testRunID, s.TestRunIDFormat.String())
+	}
+
+	if s.TestRunURLFile != "" {
+		if err := s.saveTestRunURL(); err != nil {
+			logger.Warn("failed to save test run URL", "file", s.TestRunURLFile, "err", err)
+		}
+	}
+
+	if s.OutputFile != "" {
+		if err := s.writeOutputFile(); err != nil {
+			logger.Warn("failed to write output file", "file", s.OutputFile, "err", err)
+		}
+	}
+
+	if s.TestPlanFile != "" {
+		if err := s.uploadTestPlan(); err != nil {
+			logger.Warn("failed to upload test plan", "file", s.TestPlanFile, "err", err)
+		}
+	}
+
+	if s.UseServerTime {
+		if err := s.recordClockSkew(); err != nil {
+			logger.Warn("failed to determine clock skew", "err", err)
+		}
+	}
 
 	// 2. BeforeTest on all events
 	if err := s.runLifecyclePhase("BeforeTest", func(e Event) error {
@@ -62,10 +291,21 @@ func (s *EventScheduler) Run() error {
 	}
 
 	// Send initial test event to Perfana
-	if err := s.sendTestEvent(false); err != nil {
+	if err := s.sendKeepAlive(); err != nil {
 		logger.Warn("failed to send initial test event", "err", err)
 	}
 
+	if s.PostStartCheck != nil {
+		if err := s.PostStartCheck(s.TestContext); err != nil {
+			s.runAbort()
+			if abortErr := s.Client.AbortTest(s.testRunID, s.testEventOptions()); abortErr != nil {
+				logger.Warn("failed to send abort", "err", abortErr)
+			}
+			s.stats.Status = "aborted"
+			return fmt.Errorf("post-start check failed: %w", err)
+		}
+	}
+
 	// 4. KeepAlive loop with signal handling and scheduled events
 	reason := s.runKeepAliveLoop()
 
@@ -73,12 +313,35 @@ func (s *EventScheduler) Run() error {
 	case stopSignal:
 		// 5a. Local signal abort: notify events and Perfana.
 		s.runAbort()
-		if err := s.Client.AbortTest(s.testRunID, s.buildAdditionalData()); err != nil {
+		if err := s.Client.AbortTest(s.testRunID, s.testEventOptions()); err != nil {
 			logger.Warn("failed to send abort", "err", err)
 		}
 		logger.Info("test aborted by signal")
+		s.stats.Status = "aborted"
+		s.emitStructured("aborted", map[string]interface{}{"reason": "signal"})
 		return fmt.Errorf("test aborted by signal")
 
+	case stopRequested:
+		// 5a'. Programmatic abort by an embedding program.
+		s.runAbort()
+		if err := s.Client.AbortTest(s.testRunID, s.testEventOptions()); err != nil {
+			logger.Warn("failed to send abort", "err", err)
+		}
+		s.stats.Status = "aborted"
+		s.emitStructured("aborted", map[string]interface{}{"reason": s.abortReason})
+		return fmt.Errorf("test aborted: %s", s.abortReason)
+
+	case stopSLABreach:
+		// 5a''. Real-time performance gate: record the breached SLA and abort.
+		s.runAbort()
+		s.sendSLABreachEvent(s.breachedSLA)
+		if err := s.Client.AbortTest(s.testRunID, s.testEventOptions()); err != nil {
+			logger.Warn("failed to send abort", "err", err)
+		}
+		s.stats.Status = "aborted"
+		s.emitStructured("aborted", map[string]interface{}{"reason": "sla", "metric": s.breachedSLA.Metric})
+		return fmt.Errorf("%w: %s", ErrSLABreached, s.breachedSLA.Metric)
+
 	case stopUIAbort:
 		// 5b. UI abort: Perfana already owns the abort state; just clean up events.
 		s.runAbort()
@@ -86,12 +349,40 @@ func (s *EventScheduler) Run() error {
 			return e.AfterTest(s.TestContext)
 		})
 		logger.Info("test aborted from UI, exiting gracefully")
+		s.stats.Status = "aborted from UI"
+		s.emitStructured("aborted", map[string]interface{}{"reason": "ui"})
 		return nil
+
+	case stopCompleted:
+		// 5b'. External completion: Perfana already has the completion event.
+		_ = s.runLifecyclePhase("AfterTest", func(e Event) error {
+			return e.AfterTest(s.TestContext)
+		})
+		s.stats.Status = "completed externally"
+		s.emitStructured("completed", map[string]interface{}{"reason": "external"})
+		return nil
+
+	case stopTimeout:
+		if s.NoCompleteOnTimeout {
+			// 5c. Completion is sent by an external orchestrator (e.g. run stop).
+			_ = s.runLifecyclePhase("AfterTest", func(e Event) error {
+				return e.AfterTest(s.TestContext)
+			})
+			logger.Info("test duration reached, leaving completion to the caller")
+			s.stats.Status = "timed out"
+			return nil
+		}
 	}
 
-	// 5c. Normal completion: send completed event to Perfana
+	// 5d. Normal completion: send completed event to Perfana
+	if s.ReportDeepLinkOnComplete {
+		s.addReportDeepLink()
+	}
 	if err := s.sendTestEvent(true); err != nil {
 		logger.Warn("failed to send completion event", "err", err)
+		s.stats.Errors++
+	} else {
+		s.emitStructured("completed", nil)
 	}
 
 	// 6. CheckResults on all events
@@ -102,12 +393,23 @@ func (s *EventScheduler) Run() error {
 	}
 
 	// Check Perfana results
-	if err := s.checkPerfanaResults(); err != nil {
+	resultsErr := s.checkPerfanaResults()
+	if s.ExportFile != "" {
+		if err := s.exportTestRun(); err != nil {
+			logger.Warn("failed to export test run", "file", s.ExportFile, "err", err)
+		}
+	}
+	if s.JUnitFile != "" {
+		if err := s.writeJUnitReport(); err != nil {
+			logger.Warn("failed to write JUnit report", "file", s.JUnitFile, "err", err)
+		}
+	}
+	if resultsErr != nil {
 		// Run AfterTest before returning the failure so cleanup still happens.
 		_ = s.runLifecyclePhase("AfterTest", func(e Event) error {
 			return e.AfterTest(s.TestContext)
 		})
-		return err
+		return resultsErr
 	}
 
 	// 7. AfterTest on all events
@@ -127,6 +429,7 @@ func (s *EventScheduler) runLifecyclePhase(phase string, fn func(Event) error) e
 	for _, event := range s.Events {
 		if err := fn(event); err != nil {
 			logger.Warn("event error", "phase", phase, "event", event.Name(), "err", err)
+			s.stats.Errors++
 			if s.FailOnError {
 				return fmt.Errorf("%s failed for event %s: %w", phase, event.Name(), err)
 			}
@@ -149,12 +452,42 @@ func (s *EventScheduler) runKeepAliveLoop() stopReason {
 	keepAliveTicker := time.NewTicker(keepAliveInterval)
 	defer keepAliveTicker.Stop()
 
-	testTimeout := time.After(time.Duration(s.TestDurationSec) * time.Second)
+	loopStart := time.Now()
+	s.loopStart = loopStart
+	testEnd := loopStart.Add(time.Duration(s.TestDurationSec) * time.Second)
+	testTimeout := time.After(time.Until(testEnd))
+	if s.showProgress() {
+		s.printProgress(0)
+		defer s.endProgress()
+	}
 
 	// Signal handling
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	defer signal.Stop(sigChan)
+	if !s.NoSignalHandler && !s.Client.SignalHandlerDisabled() {
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+	}
+
+	// Test duration extension; a nil channel never fires
+	var extendChan chan os.Signal
+	if s.TimeoutExtendSignal != nil && s.TimeoutExtendStep > 0 {
+		extendChan = make(chan os.Signal, 1)
+		signal.Notify(extendChan, s.TimeoutExtendSignal)
+		defer signal.Stop(extendChan)
+	}
+
+	if s.EventRateInterval > 0 {
+		s.eventLimiter = newEventRateLimiter(s.context(), s.Client, s.EventRateInterval, s.EventQueueSize)
+		defer s.eventLimiter.stop()
+	}
+
+	// SLA checks; a nil channel never fires
+	var slaTick <-chan time.Time
+	if len(s.SLAs) > 0 && s.SLAPollInterval > 0 {
+		slaTicker := time.NewTicker(s.SLAPollInterval)
+		defer slaTicker.Stop()
+		slaTick = slaTicker.C
+	}
 
 	// Prepare scheduled events sorted by delay
 	scheduleTimers := s.startScheduleTimers()
@@ -178,25 +511,105 @@ func (s *EventScheduler) runKeepAliveLoop() stopReason {
 	// Track which keep-alive participants have signaled done
 	keepAliveParticipantsDone := make(map[string]bool)
 
+	paused := false
+
+	// Marks the end of the ramp-up window; a nil channel never fires
+	var rampUpComplete <-chan time.Time
+	if s.RampUpCompleteEventTitle != "" && s.TestContext.AnalysisStartOffset > 0 {
+		rampUpComplete = time.After(time.Duration(s.TestContext.AnalysisStartOffset) * time.Second)
+	}
+
+	// Abort file polling; a nil channel never fires
+	var abortFileTick <-chan time.Time
+	if s.AbortFile != "" && s.AbortFilePollInterval > 0 {
+		abortFileTicker := time.NewTicker(s.AbortFilePollInterval)
+		defer abortFileTicker.Stop()
+		abortFileTick = abortFileTicker.C
+	}
+
+	// Timeline checkpoints; a nil channel never fires
+	var checkpointTick <-chan time.Time
+	checkpoint := 0
+	if s.CheckpointInterval > 0 && s.CheckpointNameTemplate != nil {
+		checkpointTicker := time.NewTicker(s.CheckpointInterval)
+		defer checkpointTicker.Stop()
+		checkpointTick = checkpointTicker.C
+	}
+
 	for {
 		select {
 		case <-testTimeout:
 			logger.Info("test duration reached")
-			return stopNormal
+			return stopTimeout
 
 		case <-sigChan:
 			logger.Info("signal received, aborting")
 			return stopSignal
 
-		case <-keepAliveTicker.C:
-			if err := s.sendTestEvent(false); err != nil {
-				logger.Warn("keep-alive failed", "err", err)
+		case <-s.context().Done():
+			// Cancelled before the loop started, e.g. by a signal during Init or BeforeTest
+			logger.Info("context cancelled, aborting")
+			return stopSignal
+
+		case <-abortFileTick:
+			if s.abortFileExists() {
+				logger.Info("abort file found, aborting", "file", s.AbortFile)
+				return stopSignal
 			}
 
-			if status, err := s.Client.GetTestRunStatus(s.testRunID); err == nil && status.Abort {
+		case <-extendChan:
+			testEnd = testEnd.Add(s.TimeoutExtendStep)
+			testTimeout = time.After(time.Until(testEnd))
+			s.extendTestDuration(testEnd)
+
+		case reason := <-s.abortRequests():
+			logger.Info("abort requested, aborting", "reason", reason)
+			s.abortReason = reason
+			return stopRequested
+
+		case <-rampUpComplete:
+			s.sendRampUpCompleteEvent()
+
+		case <-checkpointTick:
+			checkpoint++
+			s.sendCheckpointEvent(checkpoint)
+
+		case <-slaTick:
+			if sla := s.checkSLAs(); sla != nil {
+				s.breachedSLA = sla
+				return stopSLABreach
+			}
+
+		case <-keepAliveTicker.C:
+			s.keepAliveTicks++
+			status, statusErr := s.Client.GetTestRunStatus(s.testRunID)
+			if statusErr == nil && status.Abort {
 				logger.Info("test run aborted from UI")
 				return stopUIAbort
 			}
+			if statusErr == nil && status.Completed && s.StopOnExternalCompletion {
+				logger.Info("test run completed by another client")
+				return stopCompleted
+			}
+
+			// While the test run is paused, no keep-alives are sent to Perfana.
+			isPaused := statusErr == nil && status.Paused
+			if isPaused != paused {
+				paused = isPaused
+				if paused {
+					logger.Info("test run paused, suspending keep-alives")
+				} else {
+					logger.Info("test run resumed, sending keep-alives")
+				}
+			}
+			if !paused {
+				if err := s.sendKeepAlive(); err != nil {
+					logger.Warn("keep-alive failed", "err", err)
+				}
+			}
+			if s.showProgress() {
+				s.printProgress(time.Since(loopStart))
+			}
 
 			for _, event := range s.Events {
 				if err := event.KeepAlive(s.TestContext); err != nil {
@@ -254,9 +667,7 @@ func (s *EventScheduler) startScheduleTimers() []*time.Timer {
 				Description:     fmt.Sprintf("Scheduled event: %s", entry.EventName),
 				Tags:            s.TestContext.Tags,
 			}
-			if _, err := s.Client.SendPerfanaEvent(perfanaEvent); err != nil {
-				logger.Warn("failed to post event", "event", entry.EventName, "err", err)
-			}
+			s.sendPerfanaEvent(perfanaEvent)
 		})
 		timers = append(timers, t)
 	}
@@ -264,6 +675,50 @@ func (s *EventScheduler) startScheduleTimers() []*time.Timer {
 	return timers
 }
 
+// sendRampUpCompleteEvent posts an event marking the start of the constant load phase.
+func (s *EventScheduler) sendRampUpCompleteEvent() {
+	logger.Info("ramp-up completed", "event", s.RampUpCompleteEventTitle)
+	perfanaEvent := perfana_client.PerfanaEvent{
+		TestRunID:       s.testRunID,
+		SystemUnderTest: s.TestContext.SystemUnderTest,
+		TestEnvironment: s.TestContext.Environment,
+		Workload:        s.TestContext.Workload,
+		Title:           s.RampUpCompleteEventTitle,
+		Description:     "Ramp-up phase completed, constant load begins",
+		Tags:            s.TestContext.Tags,
+	}
+	s.sendPerfanaEvent(perfanaEvent)
+}
+
+// sendPerfanaEvent posts an event to Perfana, through the rate limiter when EventRateInterval is set.
+func (s *EventScheduler) sendPerfanaEvent(event perfana_client.PerfanaEvent) {
+	if s.eventLimiter != nil {
+		s.eventLimiter.enqueue(event)
+		return
+	}
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	if _, err := s.Client.SendPerfanaEvent(ctx, event); err != nil {
+		logger.Warn("failed to post event", "event", event.Title, "err", err)
+	}
+}
+
+// perfanaRequestTimeout limits each Init, test event and Perfana event request.
+const perfanaRequestTimeout = 30 * time.Second
+
+// context returns Context, or context.Background() when it is not set.
+func (s *EventScheduler) context() context.Context {
+	if s.Context != nil {
+		return s.Context
+	}
+	return context.Background()
+}
+
+// requestContext returns the context for a single Init, test event or Perfana event request.
+func (s *EventScheduler) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(s.context(), perfanaRequestTimeout)
+}
+
 // runAbort calls AbortTest on all events.
 func (s *EventScheduler) runAbort() {
 	for _, event := range s.Events {
@@ -302,6 +757,10 @@ func (s *EventScheduler) checkPerfanaResults() error {
 			adaptPassed := s.reportAdaptResults(result)
 			s.printDeepLink(result)
 			if !slosPassed || !adaptPassed {
+				if s.IgnoreAssertionFailures {
+					logger.Warn("test run failed its assertions, ignoring", "slos_passed", slosPassed, "adapt_passed", adaptPassed)
+					return nil
+				}
 				return fmt.Errorf("test run failed: slos_passed=%v adapt_passed=%v", slosPassed, adaptPassed)
 			}
 			return nil
@@ -380,6 +839,7 @@ func (s *EventScheduler) reportCheckResults(result *perfana_client.TestRunResult
 		logger.Warn("failed to get check results", "err", err)
 		return true // don't fail CI on fetch error
 	}
+	s.checkResults = checks
 
 	pass, fail := 0, 0
 	for _, c := range checks {
@@ -485,40 +945,133 @@ func (s *EventScheduler) printDeepLink(result *perfana_client.TestRunResult) {
 	fmt.Fprintf(os.Stdout, "   %s\n\n", link)
 }
 
-// sendTestEvent sends a keep-alive or completion event to Perfana.
-func (s *EventScheduler) sendTestEvent(completed bool) error {
-	return s.Client.TestEvent(s.testRunID, s.buildAdditionalData(), completed)
+// exportTestRun writes the JSON export of the current test run to ExportFile.
+func (s *EventScheduler) exportTestRun() error {
+	export, err := s.Client.ExportTestRun(s.testRunID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export: %w", err)
+	}
+	if err := os.WriteFile(s.ExportFile, data, 0644); err != nil {
+		return err
+	}
+	logger.Info("test run exported", "file", s.ExportFile)
+	return nil
 }
 
-// buildAdditionalData constructs the additional data map for TestEvent calls.
-func (s *EventScheduler) buildAdditionalData() map[string]interface{} {
-	data := map[string]interface{}{
-		"tags": s.TestContext.Tags,
+// recordClockSkew compares the server time with the local time halfway through
+// the request and stores the difference in milliseconds as the clockSkewMs variable.
+func (s *EventScheduler) recordClockSkew() error {
+	before := time.Now()
+	serverTime, err := s.Client.GetServerTime()
+	if err != nil {
+		return err
 	}
-	if s.TestContext.Version != "" {
-		data["version"] = s.TestContext.Version
+	after := time.Now()
+
+	localTime := before.Add(after.Sub(before) / 2)
+	skewMs := serverTime.Sub(localTime).Milliseconds()
+	if s.TestContext.Variables == nil {
+		s.TestContext.Variables = make(map[string]string)
 	}
-	if s.TestContext.Annotations != "" {
-		data["annotations"] = s.TestContext.Annotations
+	s.TestContext.Variables["clockSkewMs"] = strconv.FormatInt(skewMs, 10)
+	logger.Info("clock skew determined", "clockSkewMs", skewMs)
+	return nil
+}
+
+// addReportDeepLink appends a deep link to the Perfana report of the current
+// test run, so it is included in the completion event.
+func (s *EventScheduler) addReportDeepLink() {
+	reportURL := s.reportURL()
+	if reportURL == "" {
+		logger.Warn("appUrl not configured, skipping report deep link")
+		return
 	}
-	if s.TestContext.AnalysisStartOffset > 0 {
-		data["analysisStartOffset"] = s.TestContext.AnalysisStartOffset
+	s.TestContext.DeepLinks = append(s.TestContext.DeepLinks, perfana_client.DeepLink{
+		Name: "Perfana Report",
+		URL:  reportURL,
+		Type: "perfana",
+	})
+}
+
+// reportURL returns the Perfana dashboard URL of the test run, or "" when appUrl is not configured.
+func (s *EventScheduler) reportURL() string {
+	appUrl := s.Client.AppUrl()
+	if appUrl == "" {
+		return ""
 	}
-	if s.TestContext.Duration > 0 {
-		data["duration"] = s.TestContext.Duration
+	return fmt.Sprintf("%s/test-runs/%s", appUrl, s.testRunID)
+}
+
+// uploadTestPlan attaches the test plan file to the test run.
+func (s *EventScheduler) uploadTestPlan() error {
+	content, err := os.ReadFile(s.TestPlanFile)
+	if err != nil {
+		return err
 	}
-	if s.TestContext.BuildResultsUrl != "" {
-		data["cibuildResultsUrl"] = s.TestContext.BuildResultsUrl
+	name := s.TestPlanName
+	if name == "" {
+		name = filepath.Base(s.TestPlanFile)
 	}
-	if len(s.TestContext.DeepLinks) > 0 {
-		data["deepLinks"] = s.TestContext.DeepLinks
+	if err := s.Client.UploadAttachment(s.testRunID, name, content); err != nil {
+		return err
 	}
-	if len(s.TestContext.Variables) > 0 {
-		vars := make([]perfana_client.Variable, 0, len(s.TestContext.Variables))
-		for k, v := range s.TestContext.Variables {
-			vars = append(vars, perfana_client.Variable{Placeholder: k, Value: v})
+	logger.Info("test plan uploaded", "name", name, "bytes", len(content))
+	return nil
+}
+
+// saveTestRunURL writes the Perfana dashboard URL of the test run to TestRunURLFile.
+func (s *EventScheduler) saveTestRunURL() error {
+	reportURL := s.reportURL()
+	if reportURL == "" {
+		return fmt.Errorf("appUrl not configured")
+	}
+	return os.WriteFile(s.TestRunURLFile, []byte(reportURL+"\n"), 0644)
+}
+
+// sendKeepAlive sends a keep-alive event to Perfana and records the outcome in the run stats.
+func (s *EventScheduler) sendKeepAlive() error {
+	start := time.Now()
+	var err error
+	if s.KeepAliveFailureRate > 0 && rand.Float64() < s.KeepAliveFailureRate {
+		err = fmt.Errorf("injected keep-alive failure")
+	} else {
+		opts := s.testEventOptions()
+		if s.AnnotationsTemplateFile != "" {
+			if annotations, renderErr := s.renderAnnotationsTemplate(); renderErr != nil {
+				logger.Warn("failed to render annotations template", "file", s.AnnotationsTemplateFile, "err", renderErr)
+			} else {
+				opts.Annotations = annotations
+			}
 		}
-		data["variables"] = vars
+		ctx, cancel := s.requestContext()
+		err = s.Client.TestEvent(ctx, s.testRunID, opts, false)
+		cancel()
+	}
+	latencyMs := time.Since(start).Milliseconds()
+	s.pingHeartbeat(err)
+	if err != nil {
+		s.stats.KeepAliveErrors++
+		s.stats.Errors++
+		s.emitStructured("keepalive", map[string]interface{}{"latencyMs": latencyMs, "error": err.Error()})
+		return err
 	}
-	return data
+	s.stats.KeepAlivesSent++
+	s.emitStructured("keepalive", map[string]interface{}{"latencyMs": latencyMs})
+	return nil
+}
+
+// sendTestEvent sends a keep-alive or completion event to Perfana.
+func (s *EventScheduler) sendTestEvent(completed bool) error {
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	return s.Client.TestEvent(ctx, s.testRunID, s.testEventOptions(), completed)
+}
+
+// testEventOptions constructs the optional fields for TestEvent calls.
+func (s *EventScheduler) testEventOptions() perfana_client.TestEventOptions {
+	return s.TestContext.TestEventOptions()
 }
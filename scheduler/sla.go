@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"perfana-cli/logger"
+
+	"perfana-cli/perfana_client"
+)
+
+// ErrSLABreached is returned by Run when the run was aborted because an SLA was violated.
+var ErrSLABreached = errors.New("SLA breached")
+
+// checkSLAs fetches the current metrics of the test run and returns the first
+// SLA that is violated, or nil. Metrics that are not reported yet are skipped.
+func (s *EventScheduler) checkSLAs() *perfana_client.SLADefinition {
+	metrics, err := s.Client.GetTestRunMetrics(s.testRunID)
+	if err != nil {
+		logger.Warn("failed to fetch metrics for SLA check", "err", err)
+		return nil
+	}
+
+	for i := range s.SLAs {
+		sla := &s.SLAs[i]
+		value, ok := metrics[sla.Metric]
+		if !ok {
+			continue
+		}
+		met, err := slaMet(value, sla.Operator, sla.Threshold)
+		if err != nil {
+			logger.Warn("invalid SLA", "metric", sla.Metric, "err", err)
+			continue
+		}
+		if !met {
+			logger.Warn("SLA breached", "metric", sla.Metric, "value", value, "operator", sla.Operator, "threshold", sla.Threshold)
+			return sla
+		}
+	}
+	return nil
+}
+
+// slaMet reports whether value satisfies "value <operator> threshold".
+func slaMet(value float64, operator string, threshold float64) (bool, error) {
+	switch operator {
+	case "<", "lt":
+		return value < threshold, nil
+	case "<=", "lte":
+		return value <= threshold, nil
+	case ">", "gt":
+		return value > threshold, nil
+	case ">=", "gte":
+		return value >= threshold, nil
+	case "==", "eq":
+		return value == threshold, nil
+	}
+	return false, fmt.Errorf("unknown operator %q", operator)
+}
+
+// sendSLABreachEvent posts an event recording which SLA caused the abort. It is sent
+// directly, as the event rate limiter has stopped with the keep-alive loop.
+func (s *EventScheduler) sendSLABreachEvent(sla *perfana_client.SLADefinition) {
+	event := perfana_client.PerfanaEvent{
+		TestRunID:       s.testRunID,
+		SystemUnderTest: s.TestContext.SystemUnderTest,
+		TestEnvironment: s.TestContext.Environment,
+		Workload:        s.TestContext.Workload,
+		Title:           "Test aborted",
+		Description:     fmt.Sprintf("SLA breached: %s", sla.Metric),
+		Tags:            s.TestContext.Tags,
+	}
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	if _, err := s.Client.SendPerfanaEvent(ctx, event); err != nil {
+		logger.Warn("failed to post SLA breach event", "err", err)
+	}
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"perfana-cli/logger"
+	"strings"
+	"time"
+)
+
+// defaultSlackMessageTemplate is used when SlackMessageTemplate is empty.
+const defaultSlackMessageTemplate = "Perfana test run {testRunId} {status} after {duration}"
+
+// notifySlack posts the run outcome to the Slack incoming webhook.
+// Notification failures are logged and never affect the test run.
+func (s *EventScheduler) notifySlack() {
+	if s.SlackWebhookURL == "" {
+		return
+	}
+
+	stats := s.Stats()
+	reportURL := s.reportURL()
+
+	template := s.SlackMessageTemplate
+	if template == "" {
+		template = defaultSlackMessageTemplate
+	}
+	text := strings.NewReplacer(
+		"{testRunId}", stats.TestRunID,
+		"{status}", stats.Status,
+		"{duration}", (time.Duration(stats.DurationSec) * time.Second).String(),
+		"{reportUrl}", reportURL,
+	).Replace(template)
+	if s.SlackMessageTemplate == "" && reportURL != "" {
+		text += fmt.Sprintf("\n<%s|Perfana report>", reportURL)
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		logger.Warn("failed to build Slack message", "err", err)
+		return
+	}
+
+	client := s.HeartbeatClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(s.SlackWebhookURL, "application/json", bytes.NewReader(body))
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			err = fmt.Errorf("HTTP error: %s", resp.Status)
+		}
+	}
+	if err != nil {
+		logger.Warn("Slack notification failed", "err", err)
+	}
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"perfana-cli/perfana_client"
+)
+
+// RunStats holds runtime statistics collected while a test run is orchestrated.
+type RunStats struct {
+	TestRunID       string    `json:"testRunId"`
+	TraceID         string    `json:"traceId,omitempty"`
+	Status          string    `json:"status"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	DurationSec     int       `json:"durationSec"`
+	KeepAlivesSent  int       `json:"keepAlivesSent"`
+	KeepAliveErrors int       `json:"keepAliveErrors"`
+	Errors          int       `json:"errors"`
+
+	// Assertions holds the SLO check results of the completed test run.
+	Assertions []perfana_client.CheckResult `json:"assertions,omitempty"`
+}
+
+// Stats returns the statistics collected so far.
+func (s *EventScheduler) Stats() RunStats {
+	stats := s.stats
+	stats.TestRunID = s.testRunID
+	stats.TraceID = s.TraceID
+	stats.Assertions = s.checkResults
+	if !stats.EndTime.IsZero() {
+		stats.DurationSec = int(stats.EndTime.Sub(stats.StartTime).Seconds())
+	}
+	return stats
+}
+
+// printSummary writes the run summary to stdout, as text or as JSON
+// depending on SummaryFormat.
+func (s *EventScheduler) printSummary() {
+	stats := s.Stats()
+
+	if s.SummaryFormat == "json" {
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(os.Stdout, string(data))
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, "── Run Summary ───────────────────────────────────────────────\n")
+	fmt.Fprintf(os.Stdout, "   testRunId=%s  status=%s\n", stats.TestRunID, stats.Status)
+	if stats.TraceID != "" {
+		fmt.Fprintf(os.Stdout, "   traceId=%s\n", stats.TraceID)
+	}
+	fmt.Fprintf(os.Stdout, "   start=%s  end=%s  duration=%s\n",
+		stats.StartTime.Format(time.RFC3339), stats.EndTime.Format(time.RFC3339),
+		time.Duration(stats.DurationSec)*time.Second,
+	)
+	fmt.Fprintf(os.Stdout, "   keepAlives=%-4d  keepAliveErrors=%-4d  errors=%d\n\n",
+		stats.KeepAlivesSent, stats.KeepAliveErrors, stats.Errors,
+	)
+}
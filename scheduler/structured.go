@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// emitStructured writes a lifecycle event as a single JSON line to stdout when
+// StructuredStdout is enabled, for consumption by log aggregators.
+func (s *EventScheduler) emitStructured(event string, fields map[string]interface{}) {
+	if !s.StructuredStdout {
+		return
+	}
+	line := map[string]interface{}{
+		"event":     event,
+		"testRunId": s.testRunID,
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	for k, v := range fields {
+		line[k] = v
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(os.Stdout, string(data))
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"io"
+	"regexp"
+)
+
+// ansiEscapePattern matches ANSI CSI escape sequences such as colours and cursor movement.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// AnsiWriter writes to an underlying writer, stripping ANSI escape sequences when Strip is set.
+type AnsiWriter struct {
+	w     io.Writer
+	Strip bool
+}
+
+// NewAnsiWriter returns an AnsiWriter writing to w.
+func NewAnsiWriter(w io.Writer, strip bool) *AnsiWriter {
+	return &AnsiWriter{w: w, Strip: strip}
+}
+
+// Write writes p, without escape sequences when Strip is set. It reports len(p)
+// as written so callers are not confused by the stripped bytes.
+func (a *AnsiWriter) Write(p []byte) (int, error) {
+	if !a.Strip {
+		return a.w.Write(p)
+	}
+	if _, err := a.w.Write(ansiEscapePattern.ReplaceAll(p, nil)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
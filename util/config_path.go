@@ -0,0 +1,29 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ConfigPathEnv is the environment variable that overrides the default configuration file path.
+const ConfigPathEnv = "PERFANA_CONFIG"
+
+// ResolveConfigPath returns the configuration file path, in order of precedence:
+// flagPath (the --config flag), the PERFANA_CONFIG environment variable, and
+// ~/.perfana-cli/perfana.yaml. explicit reports whether the path was set by the
+// flag or the environment variable rather than being the default.
+func ResolveConfigPath(flagPath string) (path string, explicit bool, err error) {
+	if flagPath != "" {
+		return flagPath, true, nil
+	}
+	if envPath := os.Getenv(ConfigPathEnv); envPath != "" {
+		return envPath, true, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", false, fmt.Errorf("error finding home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".perfana-cli", "perfana.yaml"), false, nil
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadEnvFile reads KEY=VALUE lines from a .env style file and sets them as
+// environment variables of the current process. Empty lines and lines starting
+// with # are skipped, an optional "export " prefix is accepted, and values may be
+// wrapped in single or double quotes. It returns the names of the variables set.
+func LoadEnvFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		eqIdx := strings.IndexByte(line, '=')
+		if eqIdx <= 0 {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, i+1)
+		}
+		key := strings.TrimSpace(line[:eqIdx])
+		value := strings.TrimSpace(line[eqIdx+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, err)
+		}
+		names = append(names, key)
+	}
+
+	return names, nil
+}
@@ -8,61 +8,63 @@ import (
 	"time"
 )
 
-// ParseISODuration parses an ISO 8601 duration string (e.g., "PT10m") and returns the duration in minutes.
-// Note that only minutes is supported currently.
-func ParseISODuration(duration string) (int, error) {
-	// Define a regex to extract minutes. E.g., for "PT10m", this will capture "10".
-	re := regexp.MustCompile(`PT(\d+)m`)
+// isoDurationRegex matches the ISO 8601 duration grammar P[n]Y[n]M[n]W[n]DT[n]H[n]M[n]S.
+// The time part after T is optional, so date-only forms such as P30D and P2W match.
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
 
-	// Match the duration against the regex
-	matches := re.FindStringSubmatch(duration)
-	if len(matches) != 2 {
+// isoDurationUnits are the lengths of the components matched by isoDurationRegex.
+// Years count as 365 days and months as 30 days.
+var isoDurationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// ParseISODuration parses an ISO 8601 duration string (e.g., "PT10M", "PT1H30M", "P1DT2H3M4S",
+// "P30D", "P2W") and returns the total duration. Parsing is case-insensitive, so "PT5M" and "pt5m" are
+// equivalent. Zero-valued components are allowed, so "PT0S" is a zero duration.
+func ParseISODuration(duration string) (time.Duration, error) {
+	upper := strings.ToUpper(duration)
+	matches := isoDurationRegex.FindStringSubmatch(upper)
+	if matches == nil || upper == "P" || strings.HasSuffix(upper, "T") {
 		return 0, fmt.Errorf("invalid ISO 8601 duration format: %s", duration)
 	}
 
-	// Convert the matched minutes to an integer
-	minutes, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return 0, fmt.Errorf("unable to convert minutes: %v", err)
+	var total time.Duration
+	for i, unit := range isoDurationUnits {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("unable to convert %s: %v", matches[i+1], err)
+		}
+		total += time.Duration(n) * unit
 	}
 
-	return minutes, nil
+	return total, nil
 }
 
 // ParseISODurationToSeconds parses an ISO 8601 duration string and returns
-// the total duration in seconds. Supports hours (H), minutes (M), and seconds (S),
-// case-insensitive. Examples: "PT30S", "PT2M", "PT1H30M10S", "PT15m".
+// the total duration in whole seconds. Zero durations are rejected.
+// Examples: "PT30S", "PT2M", "PT1H30M10S", "PT15m", "P1D".
 func ParseISODurationToSeconds(duration string) (int, error) {
-	upper := strings.ToUpper(duration)
-	re := regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
-	matches := re.FindStringSubmatch(upper)
-	if matches == nil {
-		return 0, fmt.Errorf("invalid ISO 8601 duration format: %s", duration)
-	}
-
-	var total int
-	if matches[1] != "" {
-		h, _ := strconv.Atoi(matches[1])
-		total += h * 3600
-	}
-	if matches[2] != "" {
-		m, _ := strconv.Atoi(matches[2])
-		total += m * 60
-	}
-	if matches[3] != "" {
-		s, _ := strconv.Atoi(matches[3])
-		total += s
+	d, err := ParseISODuration(duration)
+	if err != nil {
+		return 0, err
 	}
-
-	if total == 0 {
+	if d == 0 {
 		return 0, fmt.Errorf("duration resolves to zero: %s", duration)
 	}
-
-	return total, nil
+	return int(d / time.Second), nil
 }
 
 // ParseISODurationToTimeDuration parses an ISO 8601 duration string and returns
-// a time.Duration value.
+// a time.Duration value. Zero durations are rejected.
 func ParseISODurationToTimeDuration(duration string) (time.Duration, error) {
 	seconds, err := ParseISODurationToSeconds(duration)
 	if err != nil {
@@ -70,3 +72,16 @@ func ParseISODurationToTimeDuration(duration string) (time.Duration, error) {
 	}
 	return time.Duration(seconds) * time.Second, nil
 }
+
+// ParseSecondsOrISODuration parses a plain number of seconds ("45") or an ISO 8601
+// duration ("PT45S") and returns the duration in whole seconds. Zero and negative
+// values are rejected.
+func ParseSecondsOrISODuration(value string) (int, error) {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+		if seconds <= 0 {
+			return 0, fmt.Errorf("duration must be positive: %s", value)
+		}
+		return seconds, nil
+	}
+	return ParseISODurationToSeconds(value)
+}
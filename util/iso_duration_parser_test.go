@@ -0,0 +1,82 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"PT5M", 5 * time.Minute},
+		{"PT5m", 5 * time.Minute},
+		{"PT1H", time.Hour},
+		{"pt1h", time.Hour},
+		{"P1DT2H3M4S", 24*time.Hour + 2*time.Hour + 3*time.Minute + 4*time.Second},
+		{"PT0S", 0},
+		{"p1dt2h3m4s", 24*time.Hour + 2*time.Hour + 3*time.Minute + 4*time.Second},
+		{"P0DT0H0M0S", 0},
+		{"PT0H30M", 30 * time.Minute},
+		{"P0DT1H0M0S", time.Hour},
+		{"P1D", 24 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"P0Y0M30DT0H0M0S", 30 * 24 * time.Hour},
+		{"P1Y", 365 * 24 * time.Hour},
+		{"P1M", 30 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseISODuration(tt.input)
+			if err != nil {
+				t.Fatalf("ParseISODuration(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseISODuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseISODurationInvalid(t *testing.T) {
+	for _, input := range []string{"", "P", "PT", "P1DT", "pt", "1H", "PT5X", "PT-5M"} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseISODuration(input); err == nil {
+				t.Errorf("ParseISODuration(%q) = %v, want an error", input, got)
+			}
+		})
+	}
+}
+
+func TestParseISODurationToSeconds(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"PT30S", 30},
+		{"PT1H30M10S", 5410},
+		{"P1D", 86400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseISODurationToSeconds(tt.input)
+			if err != nil {
+				t.Fatalf("ParseISODurationToSeconds(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseISODurationToSeconds(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseISODurationToSecondsInvalid(t *testing.T) {
+	for _, input := range []string{"PT0S", "P0D", "P0Y0M0DT0H0M0S", "P"} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseISODurationToSeconds(input); err == nil {
+				t.Errorf("ParseISODurationToSeconds(%q) = %d, want an error", input, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRate parses a rate of the form N/PERIOD (e.g. "10/s", "60/m", "100/h") and
+// returns the interval between two consecutive operations.
+func ParseRate(rate string) (time.Duration, error) {
+	count, period, found := strings.Cut(strings.TrimSpace(rate), "/")
+	if !found {
+		return 0, fmt.Errorf("invalid rate %q, expected N/PERIOD (e.g. 10/s)", rate)
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %q: count must be a positive number", rate)
+	}
+
+	var unit time.Duration
+	switch strings.ToLower(period) {
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	default:
+		return 0, fmt.Errorf("invalid rate %q: period must be s, m or h", rate)
+	}
+
+	interval := unit / time.Duration(n)
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid rate %q: more than one operation per nanosecond", rate)
+	}
+	return interval, nil
+}
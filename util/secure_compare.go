@@ -0,0 +1,10 @@
+package util
+
+import "crypto/subtle"
+
+// SecureCompare reports whether a and b are equal using a constant-time comparison,
+// so secrets such as API keys can be compared without leaking timing information.
+// Use it instead of == whenever one of the operands is a secret.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecureCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"equal", "secret-api-key", "secret-api-key", true},
+		{"unequal", "secret-api-key", "secret-api-kez", false},
+		{"different length", "secret-api-key", "secret-api-key-2", false},
+		{"prefix", "secret", "secret-api-key", false},
+		{"both empty", "", "", true},
+		{"one empty", "", "secret-api-key", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecureCompare(tt.a, tt.b); got != tt.want {
+				t.Errorf("SecureCompare(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSecureCompareTiming checks that the comparison time does not depend on where the
+// strings first differ: a == comparison returns at the first differing byte, so a
+// mismatch in the first byte would be much faster than one in the last byte.
+func TestSecureCompareTiming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test skipped in short mode")
+	}
+
+	const size = 64 * 1024
+	secret := strings.Repeat("a", size)
+	firstByte := "b" + secret[1:]
+	lastByte := secret[:size-1] + "b"
+
+	// The fastest of several rounds filters out scheduling noise
+	const rounds, iterations = 20, 200
+	measure := func(candidate string) time.Duration {
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			SecureCompare(secret, candidate)
+		}
+		return time.Since(start)
+	}
+	fastestFirst, fastestLast := time.Duration(1<<63-1), time.Duration(1<<63-1)
+	for r := 0; r < rounds; r++ {
+		fastestFirst = min(fastestFirst, measure(firstByte))
+		fastestLast = min(fastestLast, measure(lastByte))
+	}
+
+	ratio := float64(fastestFirst) / float64(fastestLast)
+	if ratio < 0.5 || ratio > 2 {
+		t.Errorf("mismatch in the first byte took %v, in the last byte %v (ratio %.2f); want the same time", fastestFirst, fastestLast, ratio)
+	}
+}
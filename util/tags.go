@@ -0,0 +1,19 @@
+package util
+
+import "strings"
+
+// ParseTagsString splits a delimited tag string on sep, trimming whitespace and
+// dropping empty tags. An empty sep defaults to a comma.
+func ParseTagsString(tags, sep string) []string {
+	if sep == "" {
+		sep = ","
+	}
+	var result []string
+	for _, t := range strings.Split(tags, sep) {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			result = append(result, t)
+		}
+	}
+	return result
+}
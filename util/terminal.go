@@ -0,0 +1,12 @@
+package util
+
+import "os"
+
+// IsTerminal reports whether f is connected to an interactive terminal.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}